@@ -0,0 +1,145 @@
+package num
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrInvalidTolerance is returned when a tolerance is negative or NaN
+var ErrInvalidTolerance = errors.New("invalid tolerance")
+
+// NewFromFloat64Bounded finds the fraction that is closest to the given
+// float, out of all fractions with a denominator that is no larger than
+// maxDenominator. For example, 0.333333 and 3 gives 1/3.
+// The continued fraction expansion of the exact value of the float is used,
+// including the semiconvergents. Returns the fraction and the absolute
+// difference between the fraction and the float.
+func NewFromFloat64Bounded(x float64, maxDenominator int64) (*Frac, float64, error) {
+	if maxDenominator < 1 {
+		return nil, 0, errors.New("the maximum denominator must be at least 1")
+	}
+	r := new(big.Rat).SetFloat64(x)
+	if r == nil {
+		return nil, 0, ErrNotFinite
+	}
+	return approximation(r, limitDenominator(r, big.NewInt(maxDenominator)))
+}
+
+// NewFromFloat64Within finds the fraction with the smallest denominator that
+// differs from the given float by no more than the given tolerance.
+// For example, 0.333 and 0.001 gives 1/3.
+// Returns the fraction and the absolute difference between the fraction and
+// the float.
+func NewFromFloat64Within(x, tolerance float64) (*Frac, float64, error) {
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return nil, 0, ErrInvalidTolerance
+	}
+	r := new(big.Rat).SetFloat64(x)
+	if r == nil {
+		return nil, 0, ErrNotFinite
+	}
+	if math.IsInf(tolerance, 1) {
+		return NewZero(), math.Abs(x), nil
+	}
+	tol := new(big.Rat).SetFloat64(tolerance)
+	lo := new(big.Rat).Sub(r, tol)
+	hi := new(big.Rat).Add(r, tol)
+	return approximation(r, simplestBetween(lo, hi, false))
+}
+
+// approximation converts the approximated value a to a fraction, and returns
+// it together with the absolute difference from the exact value r
+func approximation(r, a *big.Rat) (*Frac, float64, error) {
+	f, err := fromRat(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	diff, _ := new(big.Rat).Sub(r, a).Float64()
+	return f, math.Abs(diff), nil
+}
+
+// limitDenominator returns the closest fraction to r that has a denominator
+// that is no larger than maxDenominator
+func limitDenominator(r *big.Rat, maxDenominator *big.Int) *big.Rat {
+	if r.Denom().Cmp(maxDenominator) <= 0 {
+		return new(big.Rat).Set(r)
+	}
+	var (
+		p0, q0 = big.NewInt(0), big.NewInt(1)
+		p1, q1 = big.NewInt(1), big.NewInt(0)
+		n      = new(big.Int).Set(r.Num())
+		d      = new(big.Int).Set(r.Denom())
+		a, m   = new(big.Int), new(big.Int)
+		q2     = new(big.Int)
+	)
+	// Walk the convergents until the denominator would become too large
+	for {
+		a.DivMod(n, d, m)
+		q2.Mul(a, q1)
+		q2.Add(q2, q0)
+		if q2.Cmp(maxDenominator) > 0 {
+			break
+		}
+		p2 := new(big.Int).Mul(a, p1)
+		p2.Add(p2, p0)
+		p0, q0, p1, q1 = p1, q1, p2, new(big.Int).Set(q2)
+		n, d = d, new(big.Int).Set(m)
+	}
+	// The best semiconvergent with a small enough denominator
+	k := new(big.Int).Sub(maxDenominator, q0)
+	k.Div(k, q1)
+	bound1 := new(big.Rat).SetFrac(
+		new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+		new(big.Int).Add(q0, new(big.Int).Mul(k, q1)))
+	bound2 := new(big.Rat).SetFrac(p1, q1)
+	diff1 := new(big.Rat).Sub(bound1, r)
+	diff2 := new(big.Rat).Sub(bound2, r)
+	if diff2.Abs(diff2).Cmp(diff1.Abs(diff1)) <= 0 {
+		return bound2
+	}
+	return bound1
+}
+
+// simplestBetween returns the fraction with the smallest denominator in the
+// closed interval [lo, hi], where lo <= hi, or in the open interval (lo, hi),
+// where lo < hi
+func simplestBetween(lo, hi *big.Rat, open bool) *big.Rat {
+	// below checks if x is below the upper bound of the interval
+	below := func(x, bound *big.Rat) bool {
+		c := x.Cmp(bound)
+		return c < 0 || (c == 0 && !open)
+	}
+	zero := new(big.Rat)
+	switch {
+	case below(lo, zero) && below(zero, hi):
+		return zero
+	case hi.Sign() <= 0:
+		x := simplestBetween(new(big.Rat).Neg(hi), new(big.Rat).Neg(lo), open)
+		return x.Neg(x)
+	case lo.IsInt() && !open:
+		return new(big.Rat).Set(lo)
+	}
+	// 0 < lo <= hi and lo is not an integer, or 0 <= lo < hi for open intervals
+	fl := new(big.Int).Quo(lo.Num(), lo.Denom())
+	next := new(big.Rat).SetInt(fl)
+	next.Add(next, big.NewRat(1, 1))
+	if below(next, hi) {
+		return next
+	}
+	// fl <= lo < hi <= fl+1, so look for fl + 1/y, where y is the simplest
+	// fraction between 1/(hi-fl) and 1/(lo-fl)
+	whole := new(big.Rat).SetInt(fl)
+	ylo := new(big.Rat).Sub(hi, whole)
+	ylo.Inv(ylo)
+	yhi := new(big.Rat).Sub(lo, whole)
+	if yhi.Sign() == 0 {
+		// lo is an integer that is not in the open interval, and there is
+		// no upper bound for y, so y is the first integer above ylo
+		y := new(big.Rat).SetInt(new(big.Int).Quo(ylo.Num(), ylo.Denom()))
+		y.Add(y, big.NewRat(1, 1))
+		return y.Add(y.Inv(y), whole)
+	}
+	y := simplestBetween(ylo, yhi.Inv(yhi), open)
+	return y.Add(y.Inv(y), whole)
+}
@@ -0,0 +1,65 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFromFloat64Bounded(t *testing.T) {
+	tests := []struct {
+		x      float64
+		maxDen int64
+		want   string
+	}{
+		{0.333333, 10, "⅓"},
+		{math.Pi, 10, "22⁄7"},
+		{math.Pi, 1000, "355⁄113"},
+		{-math.Pi, 100, "-311⁄99"},
+		{0.6, 1, "1"},
+		{0.125, 1 << 20, "⅛"},
+	}
+	for _, test := range tests {
+		f, diff, err := NewFromFloat64Bounded(test.x, test.maxDen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.String() != test.want {
+			t.Errorf("%v with max denominator %d: expected %s, got %s", test.x, test.maxDen, test.want, f)
+		}
+		if math.Abs(diff-math.Abs(test.x-f.Float64())) > 1e-15 {
+			t.Errorf("%v: unexpected difference %v", test.x, diff)
+		}
+	}
+	if _, _, err := NewFromFloat64Bounded(math.NaN(), 10); err != ErrNotFinite {
+		t.Errorf("Expected ErrNotFinite, got %v", err)
+	}
+}
+
+func TestNewFromFloat64Within(t *testing.T) {
+	tests := []struct {
+		x, tolerance float64
+		want         string
+	}{
+		{0.333, 0.001, "⅓"},
+		{math.Pi, 0.01, "22⁄7"},
+		{math.Pi, 1e-6, "355⁄113"},
+		{-0.6666, 0.001, "-2⁄3"},
+		{2.5, 0, "5⁄2"},
+		{0.01, 0.1, "0"},
+	}
+	for _, test := range tests {
+		f, diff, err := NewFromFloat64Within(test.x, test.tolerance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.String() != test.want {
+			t.Errorf("%v within %v: expected %s, got %s", test.x, test.tolerance, test.want, f)
+		}
+		if diff > test.tolerance {
+			t.Errorf("%v within %v: the difference is %v", test.x, test.tolerance, diff)
+		}
+	}
+	if _, _, err := NewFromFloat64Within(1, -1); err != ErrInvalidTolerance {
+		t.Errorf("Expected ErrInvalidTolerance, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package num
+
+import (
+	"math/big"
+)
+
+// BigFrac is a fractional number that is stored as a Frac for as long as
+// the numerator and the denominator fit in an int64, and is promoted to
+// arbitrary precision when a result would overflow. It is demoted back
+// again as soon as the value fits, so that long chains of calculations stay
+// exact without having to choose a representation up front.
+// The zero value is 0, and is ready to use.
+type BigFrac struct {
+	small *Frac    // the value, if it fits in an int64 fraction, or nil for 0
+	big   *big.Rat // the value, if it does not fit, or nil
+}
+
+// NewBig creates a new fractional number with automatic promotion.
+// Takes a numerator and a denominator.
+func NewBig(num, dom int64) (*BigFrac, error) {
+	f, err := New(num, dom)
+	if err != nil {
+		return nil, err
+	}
+	return &BigFrac{small: f}, nil
+}
+
+// NewBigFromFrac creates a new fractional number with automatic promotion,
+// from a Frac
+func NewBigFromFrac(f *Frac) *BigFrac {
+	return &BigFrac{small: f.Copy()}
+}
+
+// NewBigFromRat creates a new fractional number with automatic promotion,
+// from a rational number (big.Rat). Nothing is truncated.
+func NewBigFromRat(r *big.Rat) *BigFrac {
+	b := &BigFrac{}
+	b.setRat(new(big.Rat).Set(r))
+	return b
+}
+
+// setRat stores the given rational number, as a Frac if it fits
+func (b *BigFrac) setRat(r *big.Rat) {
+	if f, err := fromRat(r); err == nil {
+		b.small = f
+		b.big = nil
+		return
+	}
+	b.small = nil
+	b.big = r
+}
+
+// IsBig checks if the number is currently stored with arbitrary precision
+func (b *BigFrac) IsBig() bool {
+	return b.big != nil
+}
+
+// frac returns the number as a Frac, if it is not big. A nil Frac is 0, so
+// that the zero value of BigFrac can be used.
+func (b *BigFrac) frac() *Frac {
+	if b.small == nil {
+		return NewZero()
+	}
+	return b.small
+}
+
+// Frac returns the number as a Frac, or ErrOverflow if it does not fit
+func (b *BigFrac) Frac() (*Frac, error) {
+	if b.IsBig() {
+		return nil, ErrOverflow
+	}
+	return b.frac().Copy(), nil
+}
+
+// Rat returns the number as a rational number (big.Rat)
+func (b *BigFrac) Rat() *big.Rat {
+	if b.IsBig() {
+		return new(big.Rat).Set(b.big)
+	}
+	return b.frac().Rat()
+}
+
+// Copy creates a copy
+func (b *BigFrac) Copy() *BigFrac {
+	if b.IsBig() {
+		return &BigFrac{big: new(big.Rat).Set(b.big)}
+	}
+	return &BigFrac{small: b.frac().Copy()}
+}
+
+// apply performs an operation with the checked Frac function if both numbers
+// are small, and falls back to the big.Rat function if the result overflows
+func (b *BigFrac) apply(x *BigFrac, checked func(a, b *Frac) (*Frac, error), exact func(z, a, b *big.Rat) *big.Rat) {
+	if !b.IsBig() && !x.IsBig() {
+		if f, err := checked(b.frac(), x.frac()); err == nil {
+			b.small = f
+			return
+		}
+	}
+	b.setRat(exact(new(big.Rat), b.Rat(), x.Rat()))
+}
+
+// Add another number, don't return anything
+func (b *BigFrac) Add(x *BigFrac) {
+	b.apply(x, CheckedAdd, (*big.Rat).Add)
+}
+
+// Subtract another number, don't return anything
+func (b *BigFrac) Sub(x *BigFrac) {
+	b.apply(x, CheckedSub, (*big.Rat).Sub)
+}
+
+// Multiply by another number, don't return anything
+func (b *BigFrac) Mul(x *BigFrac) {
+	b.apply(x, CheckedMul, (*big.Rat).Mul)
+}
+
+// Divide by another number.
+// Returns ErrDivByZero, and leaves the number unchanged, if x is zero.
+func (b *BigFrac) Div(x *BigFrac) error {
+	if x.IsZero() {
+		return ErrDivByZero
+	}
+	b.apply(x, CheckedDiv, (*big.Rat).Quo)
+	return nil
+}
+
+// IsZero checks if this number is 0
+func (b *BigFrac) IsZero() bool {
+	if b.IsBig() {
+		return b.big.Sign() == 0
+	}
+	return b.frac().IsZero()
+}
+
+// Return the number as a float64. Some precision may be lost.
+func (b *BigFrac) Float64() float64 {
+	if b.IsBig() {
+		f, _ := b.big.Float64()
+		return f
+	}
+	return b.frac().Float64()
+}
+
+// Return the number as a string
+func (b *BigFrac) String() string {
+	if !b.IsBig() {
+		return b.frac().String()
+	}
+	if b.big.IsInt() {
+		return b.big.Num().String()
+	}
+	return b.big.Num().String() + "⁄" + b.big.Denom().String()
+}
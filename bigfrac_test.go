@@ -0,0 +1,82 @@
+package num
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigFracPromote(t *testing.T) {
+	x := NewBigFromFrac(NewFromInt64(math.MaxInt64))
+	x.Mul(NewBigFromFrac(NewFromInt(4)))
+	if !x.IsBig() {
+		t.Errorf("Should be promoted: %s", x)
+	}
+	if _, err := x.Frac(); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	if err := x.Div(NewBigFromFrac(NewFromInt(8))); err != nil {
+		t.Fatal(err)
+	}
+	if x.IsBig() {
+		t.Errorf("Should be demoted: %s", x)
+	}
+	if x.String() != "9223372036854775807⁄2" {
+		t.Errorf("Expected 9223372036854775807⁄2, got %s", x)
+	}
+}
+
+func TestBigFracZeroValue(t *testing.T) {
+	var b BigFrac
+	if b.IsBig() || !b.IsZero() || b.String() != "0" || b.Float64() != 0 || b.Rat().Sign() != 0 {
+		t.Errorf("Expected 0, got %s", &b)
+	}
+	if f, err := b.Frac(); err != nil || !f.IsZero() {
+		t.Errorf("Expected 0, got %v (%v)", f, err)
+	}
+	b.Add(NewBigFromFrac(MustNew(1, 2)))
+	if b.String() != "½" {
+		t.Errorf("Expected 1/2, got %s", &b)
+	}
+	var zero BigFrac
+	if err := b.Div(&zero); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+	if c := zero.Copy(); !c.IsZero() {
+		t.Errorf("Expected 0, got %s", c)
+	}
+}
+
+func TestBigFracChain(t *testing.T) {
+	// The sum of 1/n for n from 1 to 60 overflows an int64 fraction
+	sum := NewBigFromFrac(NewZero())
+	for n := int64(1); n <= 60; n++ {
+		term, _ := NewBig(1, n)
+		sum.Add(term)
+	}
+	want := new(big.Rat)
+	for n := int64(1); n <= 60; n++ {
+		want.Add(want, big.NewRat(1, n))
+	}
+	if sum.Rat().Cmp(want) != 0 {
+		t.Errorf("Expected %s, got %s", want, sum)
+	}
+	// Subtract everything again, one term at a time
+	for n := int64(1); n <= 60; n++ {
+		term, _ := NewBig(1, n)
+		sum.Sub(term)
+	}
+	if !sum.IsZero() || sum.IsBig() {
+		t.Errorf("Expected 0, got %s", sum)
+	}
+	if err := sum.Div(sum); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+}
+
+func TestNewBigFromRat(t *testing.T) {
+	r, _ := new(big.Rat).SetString("123456789012345678901234567890/7")
+	if NewBigFromRat(r).Rat().Cmp(r) != 0 {
+		t.Errorf("Should not be truncated: %s", r)
+	}
+}
@@ -0,0 +1,244 @@
+package num
+
+import (
+	"math"
+	"math/big"
+)
+
+// newChecked creates a new reduced fraction from a numerator and a
+// denominator, without wrapping around if the sign can not be moved to the
+// numerator.
+func newChecked(top, bot int64) (*Frac, error) {
+	if bot == 0 {
+		return nil, ErrDivByZero
+	}
+	if g := int64(gcd(uabs(top), uabs(bot))); g != 1 {
+		top /= g
+		bot /= g
+	}
+	if bot < 0 {
+		if top == math.MinInt64 || bot == math.MinInt64 {
+			return nil, ErrOverflow
+		}
+		top, bot = -top, -bot
+	}
+	return &Frac{
+		top:           top,
+		bot:           bot,
+		maxIterations: DefaultMaxIterations,
+	}, nil
+}
+
+// fromRat converts a rational number to a fraction, or returns ErrOverflow
+// if the numerator or denominator does not fit in an int64.
+func fromRat(r *big.Rat) (*Frac, error) {
+	if !r.Num().IsInt64() || !r.Denom().IsInt64() {
+		return nil, ErrOverflow
+	}
+	return newChecked(r.Num().Int64(), r.Denom().Int64())
+}
+
+// valid checks that neither of the given fractions has a zero denominator
+func valid(fs ...*Frac) error {
+	for _, f := range fs {
+		if f.bot == 0 {
+			return ErrDivByZero
+		}
+	}
+	return nil
+}
+
+// CheckedMul multiplies two fractions and returns the result.
+// The factors are cross-reduced before multiplying, and ErrOverflow is
+// returned if the reduced result does not fit in an int64.
+func CheckedMul(a, b *Frac) (*Frac, error) {
+	if err := valid(a, b); err != nil {
+		return nil, err
+	}
+	g1 := int64(gcd(uabs(a.top), uabs(b.bot)))
+	g2 := int64(gcd(uabs(b.top), uabs(a.bot)))
+	top, ok1 := mul64(a.top/g1, b.top/g2)
+	bot, ok2 := mul64(a.bot/g2, b.bot/g1)
+	if !ok1 || !ok2 {
+		return fromRat(new(big.Rat).Mul(a.Rat(), b.Rat()))
+	}
+	return newChecked(top, bot)
+}
+
+// CheckedDiv divides two fractions and returns the result.
+// Returns ErrDivByZero if b is zero, and ErrOverflow if the reduced result
+// does not fit in an int64.
+func CheckedDiv(a, b *Frac) (*Frac, error) {
+	if err := valid(a, b); err != nil {
+		return nil, err
+	}
+	if b.top == 0 {
+		return nil, ErrDivByZero
+	}
+	g1 := int64(gcd(uabs(a.top), uabs(b.top)))
+	g2 := int64(gcd(uabs(b.bot), uabs(a.bot)))
+	top, ok1 := mul64(a.top/g1, b.bot/g2)
+	bot, ok2 := mul64(a.bot/g2, b.top/g1)
+	if !ok1 || !ok2 {
+		return fromRat(new(big.Rat).Quo(a.Rat(), b.Rat()))
+	}
+	return newChecked(top, bot)
+}
+
+// CheckedAdd adds two fractions and returns the result.
+// Returns ErrOverflow if the reduced result does not fit in an int64.
+func CheckedAdd(a, b *Frac) (*Frac, error) {
+	if err := valid(a, b); err != nil {
+		return nil, err
+	}
+	// Use the least common multiple of the denominators
+	g := int64(gcd(uabs(a.bot), uabs(b.bot)))
+	l, ok1 := mul64(a.top, b.bot/g)
+	r, ok2 := mul64(b.top, a.bot/g)
+	top, ok3 := add64(l, r)
+	bot, ok4 := mul64(a.bot, b.bot/g)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return fromRat(new(big.Rat).Add(a.Rat(), b.Rat()))
+	}
+	return newChecked(top, bot)
+}
+
+// CheckedSub subtracts two fractions and returns the result.
+// Returns ErrOverflow if the reduced result does not fit in an int64.
+func CheckedSub(a, b *Frac) (*Frac, error) {
+	if err := valid(a, b); err != nil {
+		return nil, err
+	}
+	g := int64(gcd(uabs(a.bot), uabs(b.bot)))
+	l, ok1 := mul64(a.top, b.bot/g)
+	r, ok2 := mul64(b.top, a.bot/g)
+	top, ok3 := sub64(l, r)
+	bot, ok4 := mul64(a.bot, b.bot/g)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return fromRat(new(big.Rat).Sub(a.Rat(), b.Rat()))
+	}
+	return newChecked(top, bot)
+}
+
+// CheckedMulInt multiplies a fraction with an integer and returns the result
+func CheckedMulInt(f *Frac, x int) (*Frac, error) {
+	return CheckedMul(f, NewFromInt(x))
+}
+
+// CheckedDivInt divides a fraction by an integer and returns the result
+func CheckedDivInt(f *Frac, x int) (*Frac, error) {
+	return CheckedDiv(f, NewFromInt(x))
+}
+
+// CheckedAddInt adds an integer to a fraction and returns the result
+func CheckedAddInt(f *Frac, x int) (*Frac, error) {
+	return CheckedAdd(f, NewFromInt(x))
+}
+
+// CheckedSubInt subtracts an integer from a fraction and returns the result
+func CheckedSubInt(f *Frac, x int) (*Frac, error) {
+	return CheckedSub(f, NewFromInt(x))
+}
+
+// CheckedNeg negates a fraction and returns the result.
+// Returns ErrOverflow if the reduced result does not fit in an int64.
+func CheckedNeg(f *Frac) (*Frac, error) {
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return nil, err
+	}
+	if x.top == math.MinInt64 {
+		return nil, ErrOverflow
+	}
+	x.top = -x.top
+	return x, nil
+}
+
+// set replaces the value of this fraction with the value of another,
+// but keeps the maximum number of iterations for approximations
+func (f *Frac) set(x *Frac) {
+	f.top = x.top
+	f.bot = x.bot
+}
+
+// update calls the given checked operation and stores the result in this
+// fraction. The fraction is left unchanged if an error is returned.
+func (f *Frac) update(op func(a, b *Frac) (*Frac, error), x *Frac) error {
+	result, err := op(f, x)
+	if err != nil {
+		return err
+	}
+	f.set(result)
+	return nil
+}
+
+// CheckedMul multiplies by another fraction.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedMul(x *Frac) error {
+	return f.update(CheckedMul, x)
+}
+
+// CheckedDiv divides by another fraction.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedDiv(x *Frac) error {
+	return f.update(CheckedDiv, x)
+}
+
+// CheckedAdd adds another fraction.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedAdd(x *Frac) error {
+	return f.update(CheckedAdd, x)
+}
+
+// CheckedSub subtracts another fraction.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedSub(x *Frac) error {
+	return f.update(CheckedSub, x)
+}
+
+// CheckedMulInt multiplies with an integer.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedMulInt(x int) error {
+	return f.update(CheckedMul, NewFromInt(x))
+}
+
+// CheckedDivInt divides by an integer.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedDivInt(x int) error {
+	return f.update(CheckedDiv, NewFromInt(x))
+}
+
+// CheckedAddInt adds an integer.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedAddInt(x int) error {
+	return f.update(CheckedAdd, NewFromInt(x))
+}
+
+// CheckedAddInt64 adds an int64.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedAddInt64(x int64) error {
+	return f.update(CheckedAdd, NewFromInt64(x))
+}
+
+// CheckedSubInt subtracts an integer.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedSubInt(x int) error {
+	return f.update(CheckedSub, NewFromInt(x))
+}
+
+// CheckedSubInt64 subtracts an int64.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedSubInt64(x int64) error {
+	return f.update(CheckedSub, NewFromInt64(x))
+}
+
+// CheckedNeg negates the fraction.
+// The fraction is left unchanged if an error is returned.
+func (f *Frac) CheckedNeg() error {
+	x, err := CheckedNeg(f)
+	if err != nil {
+		return err
+	}
+	f.set(x)
+	return nil
+}
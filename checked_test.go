@@ -0,0 +1,61 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckedOverflow(t *testing.T) {
+	big := NewFromInt64(math.MaxInt64)
+	if _, err := CheckedAdd(big, NewOne()); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	if _, err := CheckedMulInt(big, 2); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	if _, err := CheckedDiv(big, NewZero()); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+	x := big.Copy()
+	if err := x.CheckedAddInt64(1); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	if !x.Equal(big) {
+		t.Errorf("Should be unchanged after an error: %s", x)
+	}
+}
+
+func TestCheckedCrossReduce(t *testing.T) {
+	// Both products overflow before cross-reduction, but not after
+	a := MustNew(math.MaxInt64, 3)
+	b := MustNew(3, math.MaxInt64)
+	x, err := CheckedMul(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.String() != "1" {
+		t.Errorf("Expected 1, got %s", x)
+	}
+	// The sum of the numerators overflows, but the reduced result fits
+	c := MustNew(math.MaxInt64, 2)
+	d := MustNew(math.MaxInt64-2, 2)
+	if x, err = CheckedAdd(c, d); err != nil || !x.Equal(NewFromInt64(math.MaxInt64-1)) {
+		t.Errorf("Expected %d, got %s (%v)", int64(math.MaxInt64-1), x, err)
+	}
+	if x, err = CheckedSub(c, d); err != nil || x.String() != "1" {
+		t.Errorf("Expected 1, got %s (%v)", x, err)
+	}
+}
+
+func TestChecked(t *testing.T) {
+	x := MustNew(1, 3)
+	if err := x.CheckedAdd(MustNew(1, 6)); err != nil {
+		t.Fatal(err)
+	}
+	if x.String() != "½" {
+		t.Errorf("Expected ½, got %s", x)
+	}
+	if err := x.CheckedDivInt(0); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"errors"
 	"fmt"
-	"math/big"
 	"os"
 	"strconv"
 	"strings"
@@ -12,58 +11,112 @@ import (
 	"github.com/xyproto/num"
 )
 
-func fracAction(c *cli.Context) error {
-	if c.NArg() == 0 {
-		return errors.New("please specify a fraction or a floating point number")
-	}
-	given := c.Args().Get(0)
+// Convert a float to a fraction, by using the best approximation if a
+// maximum denominator or a tolerance is given
+func fromFloat(c *cli.Context, s float64) (*num.Frac, error) {
 	verbose := c.IsSet("verbose")
-	iterations := c.Int("maxiterations")
-
-	if strings.Contains(given, ".") {
-		s, err := strconv.ParseFloat(given, 64)
-		if err != nil {
-			return err
-		}
+	var (
+		n    *num.Frac
+		diff float64
+		err  error
+	)
+	switch {
+	case c.IsSet("max-denominator"):
+		n, diff, err = num.NewFromFloat64Bounded(s, c.Int64("max-denominator"))
+	case c.IsSet("tolerance"):
+		n, diff, err = num.NewFromFloat64Within(s, c.Float64("tolerance"))
+	default:
+		iterations := c.Int("maxiterations")
 		if verbose {
 			fmt.Println("iterations:", iterations)
 		}
-		fmt.Println(num.NewFromFloat64(s, iterations))
+		return num.NewFromFloat64(s, iterations), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if verbose {
+		fmt.Println("error:", diff)
+	}
+	return n, nil
+}
+
+// Parse the given number, and approximate it if any of the flags for
+// approximating are given
+func parse(c *cli.Context, given string) (*num.Frac, error) {
+	n, err := num.Parse(given)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsSet("maxiterations") || c.IsSet("max-denominator") || c.IsSet("tolerance") {
+		return fromFloat(c, n.Float64())
+	}
+	return n, nil
+}
+
+// The styles that can be given with --format
+var styles = map[string]num.Style{
+	"unicode": num.StyleUnicode,
+	"ascii":   num.StyleASCII,
+	"script":  num.StyleScript,
+	"latex":   num.StyleLaTeX,
+	"mathml":  num.StyleMathML,
+}
+
+// Format the terms of a continued fraction as [a0; a1, a2, ...]
+func continued(terms []int64) string {
+	var sb strings.Builder
+	for i, a := range terms {
+		switch i {
+		case 0:
+		case 1:
+			sb.WriteString("; ")
+		default:
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.FormatInt(a, 10))
+	}
+	return "[" + sb.String() + "]"
+}
+
+func fracAction(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return errors.New("please specify a fraction, a decimal number or a percentage")
+	}
+	// Mixed numbers, like "1 3/4", may be given as several arguments
+	n, err := parse(c, strings.Join(c.Args(), " "))
+	if err != nil {
+		return err
+	}
+	if c.IsSet("continued") {
+		fmt.Println(continued(n.ContinuedFraction()))
 		return nil
 	}
-	if strings.Count(given, ",") == 1 {
-		s, err := strconv.ParseFloat(strings.Replace(given, ",", ".", 1), 64)
+	if c.IsSet("decimal") {
+		s, err := n.RepeatingDecimal(num.RepetendParentheses)
 		if err != nil {
 			return err
 		}
-		if verbose {
-			fmt.Println("iterations:", iterations)
-		}
-		fmt.Println(num.NewFromFloat64(s, iterations))
+		fmt.Println(s)
 		return nil
 	}
-	if strings.Count(given, "/") == 1 {
-		n, err := num.NewFromString(given)
+	if c.IsSet("egyptian") {
+		units, err := num.Egyptian(n, num.EgyptianGreedy, 0)
 		if err != nil {
 			return err
 		}
-		fmt.Println(n)
+		terms := make([]string, len(units))
+		for i, unit := range units {
+			terms[i] = num.Format(unit, num.FormatOptions{Style: num.StyleASCII})
+		}
+		fmt.Println(strings.Join(terms, " + "))
 		return nil
 	}
-	nf := big.NewFloat(0)
-	f, b, err := nf.Parse(given, 10)
-	if err != nil {
-		return err
-	}
-	if b != 10 {
-		return fmt.Errorf("unexpected base: %d", b)
-	}
-	r, acc := f.Rat(nil)
-	if verbose {
-		fmt.Println("accuracy:", acc)
+	style, ok := styles[c.String("format")]
+	if !ok {
+		return errors.New("unknown format: " + c.String("format"))
 	}
-	n := num.NewFromRat(r)
-	fmt.Println(n)
+	fmt.Println(num.Format(n, num.FormatOptions{Style: style}))
 	return nil
 }
 
@@ -82,7 +135,7 @@ func main() {
 
 	app.Name = "frac"
 	app.Usage = "convert a float to a fraction, or simplify a fraction"
-	app.UsageText = "frac [options] [fraction or floating point number]"
+	app.UsageText = "frac [options] [fraction, mixed number, decimal number or percentage]"
 
 	app.Version = "0.2"
 	app.HideHelp = true
@@ -102,6 +155,31 @@ func main() {
 			Value: -1,
 			Usage: "maximum number of interations when converting (-1 for no limit)",
 		},
+		cli.Int64Flag{
+			Name:  "max-denominator, d",
+			Usage: "find the closest fraction with a denominator that is no larger than this",
+		},
+		cli.Float64Flag{
+			Name:  "tolerance, t",
+			Usage: "find the simplest fraction that is no further away than this",
+		},
+		cli.BoolFlag{
+			Name:  "continued, c",
+			Usage: "output the continued fraction, as [a0; a1, a2, ...]",
+		},
+		cli.BoolFlag{
+			Name:  "egyptian, e",
+			Usage: "output a sum of distinct unit fractions, like 1/2 + 1/3 + 1/12",
+		},
+		cli.StringFlag{
+			Name:  "format, f",
+			Value: "unicode",
+			Usage: "output format: unicode, ascii, script, latex or mathml",
+		},
+		cli.BoolFlag{
+			Name:  "decimal",
+			Usage: "output a decimal number, with the repeating digits in parentheses",
+		},
 	}
 
 	app.Action = fracAction
@@ -0,0 +1,121 @@
+package num
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// sign returns -1, 0 or 1, depending on the sign of the given integer
+func sign(a int64) int {
+	switch {
+	case a < 0:
+		return -1
+	case a > 0:
+		return 1
+	}
+	return 0
+}
+
+// cmpProducts compares a*b with c*d, by using 128 bit multiplication so that
+// nothing can overflow. Returns -1, 0 or 1.
+func cmpProducts(a, b, c, d int64) int {
+	s1 := sign(a) * sign(b)
+	s2 := sign(c) * sign(d)
+	if s1 != s2 {
+		return sign(int64(s1 - s2))
+	}
+	if s1 == 0 {
+		return 0
+	}
+	hi1, lo1 := bits.Mul64(uabs(a), uabs(b))
+	hi2, lo2 := bits.Mul64(uabs(c), uabs(d))
+	var cmp int
+	switch {
+	case hi1 < hi2 || (hi1 == hi2 && lo1 < lo2):
+		cmp = -1
+	case hi1 > hi2 || (hi1 == hi2 && lo1 > lo2):
+		cmp = 1
+	}
+	// A larger magnitude is smaller for negative numbers
+	return cmp * s1
+}
+
+// Cmp compares this fraction with another, without overflowing.
+// Returns -1 if f < x, 0 if f == x and 1 if f > x.
+func (f *Frac) Cmp(x *Frac) int {
+	return cmpProducts(f.top, x.bot, x.top, f.bot) * sign(f.bot) * sign(x.bot)
+}
+
+// Cmp compares two fractions, without overflowing.
+// Returns -1 if a < b, 0 if a == b and 1 if a > b.
+func Cmp(a, b *Frac) int {
+	return a.Cmp(b)
+}
+
+// Sign returns -1 if the fraction is negative, 0 if it is zero and 1 if it
+// is positive
+func (f *Frac) Sign() int {
+	return sign(f.top) * sign(f.bot)
+}
+
+// Min returns a copy of the smallest of two fractions
+func Min(a, b *Frac) *Frac {
+	if b.Cmp(a) < 0 {
+		return b.Copy()
+	}
+	return a.Copy()
+}
+
+// Max returns a copy of the largest of two fractions
+func Max(a, b *Frac) *Frac {
+	if b.Cmp(a) > 0 {
+		return b.Copy()
+	}
+	return a.Copy()
+}
+
+// Clamp returns a copy of the fraction, limited to the range from lo to hi
+func Clamp(f, lo, hi *Frac) *Frac {
+	switch {
+	case f.Cmp(lo) < 0:
+		return lo.Copy()
+	case f.Cmp(hi) > 0:
+		return hi.Copy()
+	}
+	return f.Copy()
+}
+
+// Cmp compares x with y. Returns -1 if x < y, 0 if x == y and 1 if x > y.
+func (x Fraction) Cmp(y Fraction) int {
+	return cmpProducts(x.num, y.Den(), y.num, x.Den())
+}
+
+// Sign returns -1 if x is negative, 0 if it is zero and 1 if it is positive
+func (x Fraction) Sign() int {
+	return sign(x.num)
+}
+
+// Fracs is a slice of fractions that can be sorted in increasing order
+type Fracs []*Frac
+
+func (fs Fracs) Len() int           { return len(fs) }
+func (fs Fracs) Less(i, j int) bool { return fs[i].Cmp(fs[j]) < 0 }
+func (fs Fracs) Swap(i, j int)      { fs[i], fs[j] = fs[j], fs[i] }
+
+// Sort sorts a slice of fractions in increasing order
+func Sort(fs []*Frac) {
+	sort.Sort(Fracs(fs))
+}
+
+// IsSorted checks if a slice of fractions is sorted in increasing order
+func IsSorted(fs []*Frac) bool {
+	return sort.IsSorted(Fracs(fs))
+}
+
+// Search finds the index of x in a sorted slice of fractions, or the index
+// where x would be inserted if it is not present
+func Search(fs []*Frac, x *Frac) int {
+	return sort.Search(len(fs), func(i int) bool {
+		return fs[i].Cmp(x) >= 0
+	})
+}
@@ -0,0 +1,46 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCmp(t *testing.T) {
+	a := MustNew(math.MaxInt64, math.MaxInt64-1)
+	b := MustNew(math.MaxInt64-1, math.MaxInt64-2)
+	// These products overflow an int64
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 || a.Cmp(a.Copy()) != 0 {
+		t.Errorf("Wrong order of %s and %s", a, b)
+	}
+	c := MustNew(-math.MaxInt64, math.MaxInt64-1)
+	if c.Cmp(a) != -1 || !c.LessThan(NewZero()) || c.Sign() != -1 {
+		t.Errorf("%s should be negative and less than %s", c, a)
+	}
+	if !a.GreaterThan(NewOne()) || NewZero().Sign() != 0 {
+		t.Errorf("%s should be greater than 1", a)
+	}
+	if MustNewFraction(-1, 3).Cmp(MustNewFraction(-1, 2)) != 1 {
+		t.Error("-1/3 should be greater than -1/2")
+	}
+}
+
+func TestMinMaxClamp(t *testing.T) {
+	a, b := MustNew(1, 3), MustNew(1, 2)
+	if Min(a, b).String() != "⅓" || Max(a, b).String() != "½" {
+		t.Errorf("Wrong min or max of %s and %s", a, b)
+	}
+	if Clamp(NewFromInt(2), a, b).String() != "½" || Clamp(MustNew(2, 5), a, b).String() != "⅖" {
+		t.Error("Wrong clamp")
+	}
+}
+
+func ExampleSort() {
+	fs := []*Frac{MustNew(3, 4), MustNew(-1, 2), MustNew(1, 3), NewFromInt(2)}
+	Sort(fs)
+	fmt.Println(fs)
+	fmt.Println(Search(fs, MustNew(1, 2)))
+	// Output:
+	// [-1⁄2 ⅓ 3⁄4 2]
+	// 2
+}
@@ -0,0 +1,75 @@
+package num
+
+import (
+	"errors"
+)
+
+// floorDiv divides two integers and rounds down, returning the quotient
+// and the remainder, which has the same sign as the divisor
+func floorDiv(a, b int64) (int64, int64) {
+	q, r := a/b, a%b
+	if r != 0 && (r < 0) != (b < 0) {
+		q--
+		r += b
+	}
+	return q, r
+}
+
+// ContinuedFraction returns the terms of the simple continued fraction of
+// this fraction, [a0; a1, a2, ...]. The first term is the integer part,
+// rounded down, like the integer part from Splitup for positive fractions.
+// The remaining terms are all positive.
+func (f *Frac) ContinuedFraction() []int64 {
+	var (
+		terms    []int64
+		top, bot = f.top, f.bot
+	)
+	for bot != 0 {
+		a, r := floorDiv(top, bot)
+		terms = append(terms, a)
+		top, bot = bot, r
+	}
+	return terms
+}
+
+// Convergents returns the convergents of the continued fraction of this
+// fraction. Each convergent is a better approximation than the one before,
+// and the last one is equal to the fraction itself.
+func (f *Frac) Convergents() []*Frac {
+	var (
+		terms        = f.ContinuedFraction()
+		convs        = make([]*Frac, 0, len(terms))
+		p0, q0 int64 = 1, 0
+		p1, q1 int64 = 0, 1
+	)
+	for _, a := range terms {
+		p0, q0, p1, q1 = a*p0+p1, a*q0+q1, p0, q0
+		// The convergents are already reduced
+		convs = append(convs, &Frac{top: p0, bot: q0, maxIterations: f.maxIterations})
+	}
+	return convs
+}
+
+// NewFromContinuedFraction creates a new fraction from the terms of a
+// continued fraction, [a0; a1, a2, ...].
+// Returns ErrDivByZero if a term is zero in such a way that the continued
+// fraction divides by zero, and ErrOverflow if the result does not fit.
+func NewFromContinuedFraction(terms []int64) (*Frac, error) {
+	if len(terms) == 0 {
+		return nil, errors.New("a continued fraction needs at least one term")
+	}
+	top, bot := terms[len(terms)-1], int64(1)
+	for i := len(terms) - 2; i >= 0; i-- {
+		if top == 0 {
+			return nil, ErrDivByZero
+		}
+		// a + 1/(top/bot) = (a*top + bot) / top
+		x, ok1 := mul64(terms[i], top)
+		x, ok2 := add64(x, bot)
+		if !ok1 || !ok2 {
+			return nil, ErrOverflow
+		}
+		top, bot = x, top
+	}
+	return newChecked(top, bot)
+}
@@ -0,0 +1,51 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestContinuedFraction(t *testing.T) {
+	tests := []struct {
+		f    *Frac
+		want string
+	}{
+		{MustNew(415, 93), "[4 2 6 7]"},
+		{MustNew(-415, 93), "[-5 1 1 6 7]"},
+		{MustNew(355, 113), "[3 7 16]"},
+		{NewFromInt(3), "[3]"},
+		{NewZero(), "[0]"},
+		{MustNew(math.MaxInt64, math.MaxInt64-1), "[1 9223372036854775806]"},
+	}
+	for _, test := range tests {
+		terms := test.f.ContinuedFraction()
+		if got := fmt.Sprint(terms); got != test.want {
+			t.Errorf("%s: expected %s, got %s", test.f, test.want, got)
+		}
+		f, err := NewFromContinuedFraction(terms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !f.Equal(test.f) {
+			t.Errorf("%s: reconstructed as %s", test.f, f)
+		}
+	}
+	if _, err := NewFromContinuedFraction(nil); err == nil {
+		t.Error("Expected an error for an empty continued fraction")
+	}
+	if _, err := NewFromContinuedFraction([]int64{1, 0}); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+}
+
+func ExampleFrac_Convergents() {
+	f, _ := New(355, 113)
+	for _, c := range f.Convergents() {
+		fmt.Println(c)
+	}
+	// Output:
+	// 3
+	// 22⁄7
+	// 355⁄113
+}
@@ -0,0 +1,252 @@
+package num
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RepetendStyle decides how the repeating digits of a decimal are marked
+type RepetendStyle int
+
+const (
+	// RepetendParentheses puts the repeating digits in parentheses: 0.1(6)
+	RepetendParentheses RepetendStyle = iota
+	// RepetendOverline puts a combining overline over the repeating digits: 0.16̅
+	RepetendOverline
+	// RepetendEllipsis repeats the repeating digits and adds "...": 0.1666...
+	RepetendEllipsis
+)
+
+// MaxRepetendDigits is the largest number of repeating digits that
+// RepeatingDecimal will output
+const MaxRepetendDigits = 100000
+
+// ErrRepetendTooLong is returned by RepeatingDecimal when there are more than
+// MaxRepetendDigits repeating digits
+var ErrRepetendTooLong = errors.New("too many repeating digits")
+
+const (
+	overline = '̅' // combining overline
+	macron   = '̄' // combining macron
+)
+
+// decimalDigits returns the integer part and the digits after the decimal
+// point of the absolute value of the fraction, split into the digits that
+// do not repeat and the digits that repeat
+func (f *Frac) decimalDigits() (whole uint64, prefix, repetend string, err error) {
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return 0, "", "", err
+	}
+	n, d := uabs(x.top), uint64(x.bot)
+	whole, r := n/d, n%d
+	// The number of digits that do not repeat is the largest exponent of 2
+	// or 5 in the denominator, and the rest of the denominator decides if
+	// the decimal is repeating
+	var twos, fives int
+	rest := d
+	for rest%2 == 0 {
+		rest /= 2
+		twos++
+	}
+	for rest%5 == 0 {
+		rest /= 5
+		fives++
+	}
+	pre := twos
+	if fives > pre {
+		pre = fives
+	}
+	// Long division, without overflowing when multiplying the remainder by 10
+	next := func() byte {
+		hi, lo := bits.Mul64(r, 10)
+		var q uint64
+		q, r = bits.Div64(hi, lo, d)
+		return byte('0' + q)
+	}
+	var sb strings.Builder
+	for i := 0; i < pre; i++ {
+		sb.WriteByte(next())
+	}
+	prefix = sb.String()
+	if rest == 1 {
+		return whole, prefix, "", nil
+	}
+	sb.Reset()
+	start := r
+	for {
+		sb.WriteByte(next())
+		if r == start {
+			break
+		}
+		if sb.Len() >= MaxRepetendDigits {
+			return 0, "", "", ErrRepetendTooLong
+		}
+	}
+	return whole, prefix, sb.String(), nil
+}
+
+// RepeatingDecimal returns the fraction as a decimal number, with the
+// repeating digits marked in the given style. For example, 1/6 is "0.1(6)",
+// "0.16̅" or "0.1666...", and 1/8 is "0.125" in all styles.
+// Returns ErrRepetendTooLong if there are more than MaxRepetendDigits
+// repeating digits.
+func (f *Frac) RepeatingDecimal(style RepetendStyle) (string, error) {
+	whole, prefix, repetend, err := f.decimalDigits()
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if f.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(strconv.FormatUint(whole, 10))
+	if prefix == "" && repetend == "" {
+		return sb.String(), nil
+	}
+	sb.WriteByte('.')
+	sb.WriteString(prefix)
+	if repetend == "" {
+		return sb.String(), nil
+	}
+	switch style {
+	case RepetendOverline:
+		for _, digit := range repetend {
+			sb.WriteRune(digit)
+			sb.WriteRune(overline)
+		}
+	case RepetendEllipsis:
+		// Repeat the digits at least twice, and so that there are at least
+		// three repeating digits
+		sb.WriteString(repetend)
+		for i := len(repetend); i < 3 || i == len(repetend); i += len(repetend) {
+			sb.WriteString(repetend)
+		}
+		sb.WriteString("...")
+	default:
+		sb.WriteByte('(')
+		sb.WriteString(repetend)
+		sb.WriteByte(')')
+	}
+	return sb.String(), nil
+}
+
+// repeatingParentheses matches a decimal number where the repeating digits
+// are in parentheses, like "0.1(6)"
+var repeatingParentheses = regexp.MustCompile(`^([+-]?)(\d*)[.,](\d*)\((\d+)\)$`)
+
+// repeatingEllipsis matches a decimal number that ends with an ellipsis,
+// like "0.1666..."
+var repeatingEllipsis = regexp.MustCompile(`^([+-]?)(\d*)[.,](\d+)(?:\.\.\.|…)$`)
+
+// isRepeating checks if the string looks like a repeating decimal
+func isRepeating(s string) bool {
+	return strings.ContainsAny(s, "(…"+string(overline)+string(macron)) || strings.HasSuffix(s, "...")
+}
+
+// parseRepeating parses a repeating decimal, where the repeating digits are
+// in parentheses ("0.1(6)"), have a combining overline or macron over them
+// ("0.16̅") or are repeated before an ellipsis ("0.1666...")
+func parseRepeating(s string) (*Frac, error) {
+	if m := repeatingParentheses.FindStringSubmatch(s); m != nil {
+		return newRepeating(m[1] == "-", m[2], m[3], m[4])
+	}
+	if m := repeatingEllipsis.FindStringSubmatch(s); m != nil {
+		prefix, repetend := findRepetend(m[3])
+		if repetend == "" {
+			return nil, errors.New("No repeating digits before the ellipsis: " + s)
+		}
+		return newRepeating(m[1] == "-", m[2], prefix, repetend)
+	}
+	// Digits with an overline or a macron over them
+	var (
+		sign, whole string
+		digits      []rune
+		marked      []bool
+	)
+	rest := s
+	if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+		sign, rest = rest[:1], rest[1:]
+	}
+	i := strings.IndexAny(rest, ".,")
+	if i < 0 {
+		return nil, errors.New("This doesn't look like a repeating decimal: " + s)
+	}
+	whole, rest = rest[:i], rest[i+1:]
+	for _, r := range rest {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, r)
+			marked = append(marked, false)
+		case (r == overline || r == macron) && len(digits) > 0:
+			marked[len(marked)-1] = true
+		default:
+			return nil, errors.New("This doesn't look like a repeating decimal: " + s)
+		}
+	}
+	// The marked digits must be at the end
+	first := len(digits)
+	for first > 0 && marked[first-1] {
+		first--
+	}
+	for _, m := range marked[:first] {
+		if m {
+			return nil, errors.New("The repeating digits must be at the end: " + s)
+		}
+	}
+	if strings.Trim(whole, "0123456789") != "" || first == len(digits) {
+		return nil, errors.New("This doesn't look like a repeating decimal: " + s)
+	}
+	return newRepeating(sign == "-", whole, string(digits[:first]), string(digits[first:]))
+}
+
+// findRepetend splits the digits into the digits that do not repeat and
+// the repeating digits, by finding the shortest prefix, and then the
+// shortest repetend, so that the rest of the digits consist of at least two
+// copies of the repetend, where the last copy may be cut short
+func findRepetend(digits string) (string, string) {
+	for a := 0; a < len(digits); a++ {
+		rest := digits[a:]
+		for p := 1; 2*p <= len(rest); p++ {
+			if periodic(rest, p) {
+				return digits[:a], rest[:p]
+			}
+		}
+	}
+	return digits, ""
+}
+
+// periodic checks if the string repeats with the given period
+func periodic(s string, p int) bool {
+	for i := p; i < len(s); i++ {
+		if s[i] != s[i-p] {
+			return false
+		}
+	}
+	return true
+}
+
+// newRepeating creates a new fraction from the whole number, the digits
+// after the decimal point that do not repeat, and the repeating digits.
+// The value is whole + (prefix+repetend - prefix) / (10^a * (10^r - 1)),
+// where a and r are the number of digits in the prefix and the repetend.
+func newRepeating(negative bool, whole, prefix, repetend string) (*Frac, error) {
+	ten := big.NewInt(10)
+	w, _ := new(big.Int).SetString("0"+whole, 10)
+	all, _ := new(big.Int).SetString("0"+prefix+repetend, 10)
+	pre, _ := new(big.Int).SetString("0"+prefix, 10)
+	top := all.Sub(all, pre)
+	bot := new(big.Int).Exp(ten, big.NewInt(int64(len(repetend))), nil)
+	bot.Sub(bot, big.NewInt(1))
+	bot.Mul(bot, new(big.Int).Exp(ten, big.NewInt(int64(len(prefix))), nil))
+	r := new(big.Rat).SetFrac(top, bot)
+	r.Add(r, new(big.Rat).SetInt(w))
+	if negative {
+		r.Neg(r)
+	}
+	return fromRat(r)
+}
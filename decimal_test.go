@@ -0,0 +1,99 @@
+package num
+
+import (
+	"testing"
+)
+
+func TestParseRepeating(t *testing.T) {
+	tests := []struct {
+		s          string
+		top, bot   int64
+		shouldFail bool
+	}{
+		{s: "0.(142857)", top: 1, bot: 7},
+		{s: "0.1(6)", top: 1, bot: 6},
+		{s: "-0.1(6)", top: -1, bot: 6},
+		{s: "1.(3)", top: 4, bot: 3},
+		{s: ".(9)", top: 1, bot: 1},
+		{s: "0,(3)", top: 1, bot: 3},
+		{s: "0.16̅", top: 1, bot: 6},
+		{s: "0.1̅6̅", top: 16, bot: 99},
+		{s: "0.14̄", top: 13, bot: 90},
+		{s: "0.1666...", top: 1, bot: 6},
+		{s: "0.142857142857…", top: 1, bot: 7},
+		{s: "0.1212...", top: 4, bot: 33},
+		{s: "0.10111011...", top: 337, bot: 3333},
+		{s: "2.333...", top: 7, bot: 3},
+		{s: "12.5(3)%", top: 47, bot: 375},
+		{s: "0.123...", shouldFail: true},
+		{s: "0.1̅6", shouldFail: true},
+		{s: "0.(12", shouldFail: true},
+		{s: "0.()", shouldFail: true},
+		{s: "0.(" + "9999999999999999999999" + ")", top: 1, bot: 1},
+		{s: "0.(" + "1234567890123456789012" + ")", shouldFail: true},
+	}
+	for _, test := range tests {
+		f, err := Parse(test.s)
+		if test.shouldFail {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %s", test.s, f)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+			continue
+		}
+		if f.top != test.top || f.bot != test.bot {
+			t.Errorf("%q: expected %d/%d, got %d/%d", test.s, test.top, test.bot, f.top, f.bot)
+		}
+	}
+}
+
+func TestRepeatingDecimal(t *testing.T) {
+	tests := []struct {
+		top, bot                       int64
+		parentheses, overline, ellipse string
+	}{
+		{1, 7, "0.(142857)", "0.1̅4̅2̅8̅5̅7̅", "0.142857142857..."},
+		{1, 6, "0.1(6)", "0.16̅", "0.1666..."},
+		{-1, 6, "-0.1(6)", "-0.16̅", "-0.1666..."},
+		{4, 33, "0.(12)", "0.1̅2̅", "0.1212..."},
+		{22, 7, "3.(142857)", "3.1̅4̅2̅8̅5̅7̅", "3.142857142857..."},
+		{1, 8, "0.125", "0.125", "0.125"},
+		{-3, 1, "-3", "-3", "-3"},
+		{0, 1, "0", "0", "0"},
+		{1, 3 * 1024, "0.0003255208(3)", "0.00032552083̅", "0.0003255208333..."},
+	}
+	for _, test := range tests {
+		f := MustNew(test.top, test.bot)
+		for style, want := range []string{test.parentheses, test.overline, test.ellipse} {
+			s, err := f.RepeatingDecimal(RepetendStyle(style))
+			if err != nil {
+				t.Errorf("%s: %v", f, err)
+				continue
+			}
+			if s != want {
+				t.Errorf("%s in style %d: expected %s, got %s", f, style, want, s)
+			}
+			// Parsing the decimal must give back the same fraction
+			g, err := Parse(s)
+			if err != nil {
+				t.Errorf("%s: %v", s, err)
+				continue
+			}
+			if g.Cmp(f) != 0 {
+				t.Errorf("%s: expected %s, got %s", s, f, g)
+			}
+		}
+	}
+	// The denominator is a large prime, so the repetend is very long
+	if _, err := MustNew(1, 1000000007).RepeatingDecimal(RepetendParentheses); err != ErrRepetendTooLong {
+		t.Errorf("expected ErrRepetendTooLong, got %v", err)
+	}
+	// Large numbers must not overflow in the long division
+	s, err := MustNew(-9223372036854775807, 1<<62).RepeatingDecimal(RepetendParentheses)
+	if err != nil || s[:5] != "-1.99" {
+		t.Errorf("expected -1.99..., got %s, %v", s, err)
+	}
+}
@@ -0,0 +1,237 @@
+package num
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+// EgyptianAlgorithm decides how Egyptian finds the unit fractions
+type EgyptianAlgorithm int
+
+const (
+	// EgyptianGreedy uses the greedy Fibonacci–Sylvester algorithm, that
+	// always takes the largest unit fraction that is not larger than what
+	// is left. It is fast, but the denominators may grow very large.
+	EgyptianGreedy EgyptianAlgorithm = iota
+	// EgyptianBinary uses the binary remainder method, where the
+	// denominators are powers of two, or the denominator of the fraction
+	// times powers of two. Only for fractions that are smaller than 1.
+	EgyptianBinary
+	// EgyptianMinimal searches for the decomposition with the fewest terms,
+	// and then the smallest largest denominator. Can be slow.
+	EgyptianMinimal
+)
+
+// DefaultEgyptianMaxDenominator is the largest denominator that
+// EgyptianMinimal uses when no limit is given to Egyptian. The search gets
+// slower as the limit grows, and as more terms are needed, and gives up with
+// ErrMaxIterations when it takes too long.
+const DefaultEgyptianMaxDenominator = 1000
+
+// ErrMaxDenominator is returned by Egyptian when the decomposition needs a
+// denominator that is larger than the given limit
+var ErrMaxDenominator = errors.New("the denominator is too large")
+
+// Egyptian decomposes a positive fraction into a sum of distinct unit
+// fractions, like 1/2 + 1/3 + 1/12 for 11/12, with the given algorithm.
+// The unit fractions are sorted from the largest to the smallest.
+// maxDen is the largest denominator that may be used, or 0 for no limit.
+// With EgyptianMinimal and no limit, DefaultEgyptianMaxDenominator is used
+// as the limit, since the search needs one.
+// Returns ErrDomain if the fraction is not positive, or if it is not
+// smaller than 1 for EgyptianBinary, ErrMaxDenominator if no decomposition
+// is found within maxDen, ErrMaxIterations if EgyptianMinimal gives up
+// before the search is done, and ErrOverflow if a denominator does not fit
+// in an int64.
+func Egyptian(f *Frac, algorithm EgyptianAlgorithm, maxDen int64) ([]*Frac, error) {
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return nil, err
+	}
+	if x.top <= 0 {
+		return nil, ErrDomain
+	}
+	var dens []int64
+	switch algorithm {
+	case EgyptianBinary:
+		if x.top >= x.bot {
+			return nil, ErrDomain
+		}
+		dens, err = egyptianBinary(x)
+	case EgyptianMinimal:
+		limit := maxDen
+		if limit <= 0 {
+			limit = DefaultEgyptianMaxDenominator
+		}
+		dens, err = egyptianMinimal(x.Rat(), limit)
+		if err == nil && dens == nil {
+			return nil, ErrMaxDenominator
+		}
+	default:
+		dens, err = egyptianGreedy(x)
+	}
+	if err != nil {
+		return nil, err
+	}
+	units := make([]*Frac, len(dens))
+	for i, den := range dens {
+		if maxDen > 0 && den > maxDen {
+			return nil, ErrMaxDenominator
+		}
+		units[i] = &Frac{top: 1, bot: den, maxIterations: x.maxIterations}
+	}
+	return units, nil
+}
+
+// egyptianGreedy returns the denominators of the greedy decomposition of a
+// positive fraction. For fractions that are larger than 1, the denominators
+// 1, 2, 3... are used for as long as possible.
+func egyptianGreedy(x *Frac) ([]int64, error) {
+	var (
+		dens []int64
+		last int64
+	)
+	for x.top != 0 {
+		// The smallest n where 1/n <= top/bot is the ceiling of bot/top
+		n := (x.bot-1)/x.top + 1
+		if n <= last {
+			n = last + 1
+		}
+		rest, err := CheckedSub(x, &Frac{top: 1, bot: n})
+		if err != nil {
+			return nil, err
+		}
+		dens = append(dens, n)
+		x, last = rest, n
+	}
+	return dens, nil
+}
+
+// egyptianBinary returns the denominators of the binary remainder
+// decomposition of a fraction between 0 and 1. With N = 2^k >= bot and
+// top*N = q*bot + r, top/bot = q/N + r/(bot*N), and the bits of q and r
+// give the unit fractions.
+func egyptianBinary(x *Frac) ([]int64, error) {
+	k := uint(bits.Len64(uint64(x.bot - 1)))
+	n := new(big.Int).Lsh(big.NewInt(1), k)
+	q, r := new(big.Int).DivMod(new(big.Int).Mul(big.NewInt(x.top), n), big.NewInt(x.bot), new(big.Int))
+	var dens []int64
+	for _, part := range []struct{ bits, den *big.Int }{
+		{q, n},
+		{r, new(big.Int).Mul(big.NewInt(x.bot), n)},
+	} {
+		for i := part.bits.BitLen() - 1; i >= 0; i-- {
+			if part.bits.Bit(i) == 0 {
+				continue
+			}
+			den := new(big.Int).Rsh(part.den, uint(i))
+			if !den.IsInt64() {
+				return nil, ErrOverflow
+			}
+			dens = append(dens, den.Int64())
+		}
+	}
+	return dens, nil
+}
+
+// egyptianMaxNodes is the number of steps that egyptianMinimal may use in
+// its search, so that it always returns in reasonable time
+const egyptianMaxNodes = 250000
+
+// egyptianMinimal returns the denominators of the decomposition with the
+// fewest terms, and then the smallest largest denominator, where all the
+// denominators are at most maxDen. Returns nil if there is none, and
+// ErrMaxIterations if the search is not done after egyptianMaxNodes steps.
+func egyptianMinimal(x *big.Rat, maxDen int64) ([]int64, error) {
+	if !denominatorPossible(x.Denom().Int64(), maxDen) {
+		return nil, nil
+	}
+	nodes := egyptianMaxNodes
+	// There can not be more terms than there are denominators to choose from
+	for terms := int64(1); terms <= maxDen; terms++ {
+		var best []int64
+		egyptianSearch(x, 1, terms, maxDen, nil, &best, &nodes)
+		if nodes <= 0 {
+			// The search was cut short, so best may not be the best
+			return nil, ErrMaxIterations
+		}
+		if best != nil {
+			return best, nil
+		}
+	}
+	return nil, nil
+}
+
+// egyptianSearch finds the best way to write x as a sum of the given number
+// of unit fractions with increasing denominators from lowest up to highest,
+// that comes after the denominators in dens. The best result so far is in
+// best. Every call uses one of the steps that are left in nodes, and nothing
+// is searched when there are none left.
+func egyptianSearch(x *big.Rat, lowest, terms, highest int64, dens []int64, best *[]int64, nodes *int) {
+	if *nodes <= 0 {
+		return
+	}
+	*nodes--
+	// Every denominator that follows must be smaller than the largest
+	// denominator of the best result so far
+	if *best != nil && (*best)[len(*best)-1]-1 < highest {
+		highest = (*best)[len(*best)-1] - 1
+	}
+	top, bot := x.Num(), x.Denom()
+	if terms == 1 {
+		if top.IsInt64() && top.Int64() == 1 && bot.IsInt64() && bot.Int64() >= lowest && bot.Int64() <= highest {
+			*best = append(append([]int64(nil), dens...), bot.Int64())
+		}
+		return
+	}
+	// 1/n <= x, so n >= bot/top, and terms/n >= x, so n <= terms*bot/top
+	lo := new(big.Int).Add(bot, new(big.Int).Sub(top, big.NewInt(1)))
+	lo.Quo(lo, top)
+	if !lo.IsInt64() {
+		return
+	}
+	if lo.Int64() > lowest {
+		lowest = lo.Int64()
+	}
+	upper := highest
+	if hi := new(big.Int).Quo(new(big.Int).Mul(big.NewInt(terms), bot), top); hi.IsInt64() && hi.Int64() < upper {
+		upper = hi.Int64()
+	}
+	for n := lowest; n <= upper && n < highest; n++ {
+		rest := new(big.Rat).Sub(x, big.NewRat(1, n))
+		if rest.Sign() <= 0 {
+			continue
+		}
+		egyptianSearch(rest, n+1, terms-1, highest, append(dens, n), best, nodes)
+		// Every denominator that follows must be smaller than the largest
+		// denominator of a better result
+		if *best != nil && (*best)[len(*best)-1]-1 < highest {
+			highest = (*best)[len(*best)-1] - 1
+		}
+	}
+}
+
+// denominatorPossible checks if a sum of unit fractions with denominators
+// that are at most maxDen can have the given denominator. The denominator
+// of the sum divides lcm(1, 2, ..., maxDen), so every prime power in the
+// denominator must be at most maxDen. Only small primes are checked.
+func denominatorPossible(den, maxDen int64) bool {
+	const maxPrime = 1 << 20
+	for p := int64(2); p <= maxDen && p <= maxPrime; p++ {
+		if p*p > den {
+			// What is left of den is 1 or a prime
+			return den <= maxDen
+		}
+		power := int64(1)
+		for den%p == 0 {
+			den /= p
+			power *= p
+		}
+		if power > maxDen {
+			return false
+		}
+	}
+	// Only if maxDen is large and den has large prime factors
+	return den == 1 || maxDen > maxPrime
+}
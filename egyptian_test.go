@@ -0,0 +1,88 @@
+package num
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEgyptian(t *testing.T) {
+	tests := []struct {
+		top, bot  int64
+		algorithm EgyptianAlgorithm
+		maxDen    int64
+		want      []int64
+		err       error
+	}{
+		{11, 12, EgyptianGreedy, 0, []int64{2, 3, 12}, nil},
+		{4, 13, EgyptianGreedy, 0, []int64{4, 18, 468}, nil},
+		{7, 4, EgyptianGreedy, 0, []int64{1, 2, 4}, nil},
+		{1, 1, EgyptianGreedy, 0, []int64{1}, nil},
+		{1, 7, EgyptianGreedy, 0, []int64{7}, nil},
+		{5, 121, EgyptianGreedy, 0, nil, ErrOverflow},
+		{4, 13, EgyptianGreedy, 100, nil, ErrMaxDenominator},
+		{11, 12, EgyptianBinary, 0, []int64{2, 4, 8, 24}, nil},
+		{1, 3, EgyptianBinary, 0, []int64{4, 12}, nil},
+		{1, 2, EgyptianBinary, 0, []int64{2}, nil},
+		{3, 2, EgyptianBinary, 0, nil, ErrDomain},
+		{4, 13, EgyptianMinimal, 0, []int64{4, 26, 52}, nil},
+		{11, 12, EgyptianMinimal, 0, []int64{2, 4, 6}, nil},
+		{5, 121, EgyptianMinimal, 2000, []int64{33, 121, 363}, nil},
+		{5, 121, EgyptianMinimal, 0, []int64{33, 121, 363}, nil},
+		{3, 7, EgyptianMinimal, 100, []int64{4, 7, 28}, nil},
+		{7, 4, EgyptianMinimal, 10, []int64{1, 2, 4}, nil},
+		{1, 7, EgyptianMinimal, 6, nil, ErrMaxDenominator},
+		{3, 7, EgyptianMinimal, 27, []int64{6, 7, 14, 21}, nil},
+		{2, 49, EgyptianMinimal, 48, nil, ErrMaxDenominator},
+		{0, 1, EgyptianGreedy, 0, nil, ErrDomain},
+		{-1, 2, EgyptianMinimal, 10, nil, ErrDomain},
+	}
+	for _, test := range tests {
+		f := MustNew(test.top, test.bot)
+		units, err := Egyptian(f, test.algorithm, test.maxDen)
+		if err != test.err {
+			t.Errorf("%s with algorithm %d: expected error %v, got %v", f, test.algorithm, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(units) != len(test.want) {
+			t.Errorf("%s with algorithm %d: expected %v, got %v", f, test.algorithm, test.want, units)
+			continue
+		}
+		sum := NewZero()
+		for i, unit := range units {
+			if unit.top != 1 || unit.bot != test.want[i] {
+				t.Errorf("%s with algorithm %d: expected %v, got %v", f, test.algorithm, test.want, units)
+				break
+			}
+			sum.Add(unit)
+		}
+		if !sum.Equal(f) {
+			t.Errorf("%s with algorithm %d: the sum is %s", f, test.algorithm, sum)
+		}
+	}
+}
+
+func TestEgyptianMinimalGivesUp(t *testing.T) {
+	start := time.Now()
+	for _, f := range []*Frac{MustNew(997, 998), MustNew(31, 311)} {
+		if _, err := Egyptian(f, EgyptianMinimal, 0); err != ErrMaxIterations {
+			t.Errorf("%s: expected ErrMaxIterations, got %v", f, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Second {
+		t.Errorf("expected the search to give up quickly, it took %s", elapsed)
+	}
+}
+
+func ExampleEgyptian() {
+	units, err := Egyptian(MustNew(4, 13), EgyptianMinimal, 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(units)
+	// Output:
+	// [¼ 1⁄26 1⁄52]
+}
@@ -0,0 +1,70 @@
+package num
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// ErrNotFinite is returned when converting NaN or ±Inf to a fraction
+var ErrNotFinite = errors.New("not a finite number")
+
+// NewFromFloat64Exact converts a float to the fraction that has exactly the
+// same value. The float is decomposed into its mantissa and exponent, so the
+// denominator is always a power of two, and no iterations are needed.
+// Returns ErrNotFinite for NaN and ±Inf, and ErrOverflow if the numerator or
+// denominator does not fit in an int64. See NewBigFromFloat64 for the latter.
+func NewFromFloat64Exact(f float64) (*Frac, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, ErrNotFinite
+	}
+	b := math.Float64bits(f)
+	mant := b & (1<<52 - 1)
+	exp := int((b >> 52) & 0x7ff)
+	if exp == 0 {
+		// Subnormal number, or zero
+		exp = 1
+	} else {
+		mant |= 1 << 52
+	}
+	if mant == 0 {
+		return NewZero(), nil
+	}
+	// The value is mant * 2^exp, with the trailing zero bits of the mantissa
+	// moved over to the exponent
+	exp -= 1075
+	tz := bits.TrailingZeros64(mant)
+	mant >>= uint(tz)
+	exp += tz
+	top, bot := int64(mant), int64(1)
+	switch {
+	case exp > 0:
+		if bits.Len64(mant)+exp > 63 {
+			return nil, ErrOverflow
+		}
+		top <<= uint(exp)
+	case exp < 0:
+		if -exp > 62 {
+			return nil, ErrOverflow
+		}
+		bot <<= uint(-exp)
+	}
+	if math.Signbit(f) {
+		top = -top
+	}
+	return New(top, bot)
+}
+
+// NewBigFromFloat64 converts a float to a fractional number with automatic
+// promotion, that has exactly the same value.
+// Returns ErrNotFinite for NaN and ±Inf.
+func NewBigFromFloat64(f float64) (*BigFrac, error) {
+	r := new(big.Rat).SetFloat64(f)
+	if r == nil {
+		return nil, ErrNotFinite
+	}
+	b := &BigFrac{}
+	b.setRat(r)
+	return b, nil
+}
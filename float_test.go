@@ -0,0 +1,52 @@
+package num
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewFromFloat64Exact(t *testing.T) {
+	for _, x := range []float64{0, 1, -1, 0.5, 0.1, -0.0078125, 3.14159265359, 1 << 62, -(1 << 62), 1.0 / (1 << 62)} {
+		f, err := NewFromFloat64Exact(x)
+		if err != nil {
+			t.Fatalf("%v: %v", x, err)
+		}
+		if f.Rat().Cmp(new(big.Rat).SetFloat64(x)) != 0 {
+			t.Errorf("%v: not exact: %s", x, f)
+		}
+		if !f.ExactFloat64() || f.Float64() != x {
+			t.Errorf("%v: does not convert back: %v", x, f.Float64())
+		}
+	}
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewFromFloat64Exact(x); err != ErrNotFinite {
+			t.Errorf("%v: expected ErrNotFinite, got %v", x, err)
+		}
+	}
+	for _, x := range []float64{1 << 63, 1e-20, math.SmallestNonzeroFloat64} {
+		if _, err := NewFromFloat64Exact(x); err != ErrOverflow {
+			t.Errorf("%v: expected ErrOverflow, got %v", x, err)
+		}
+	}
+}
+
+func TestNewBigFromFloat64(t *testing.T) {
+	// The exact denominator of 0.000123 is too large for an int64
+	b, err := NewBigFromFloat64(0.000123)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsBig() || b.Float64() != 0.000123 {
+		t.Errorf("Expected an exact big fraction, got %s", b)
+	}
+	if _, err := NewBigFromFloat64(math.NaN()); err != ErrNotFinite {
+		t.Errorf("Expected ErrNotFinite, got %v", err)
+	}
+}
+
+func BenchmarkNewFromFloat64Exact(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewFromFloat64Exact(0.1)
+	}
+}
@@ -0,0 +1,249 @@
+package num
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Style decides how Format writes a fraction
+type Style int
+
+const (
+	// StyleUnicode uses a vulgar fraction character if there is one, and
+	// the fraction slash (U+2044) if not, like String: "⅜" or "3⁄16"
+	StyleUnicode Style = iota
+	// StyleASCII uses only ASCII characters: "3/8"
+	StyleASCII
+	// StyleScript uses superscript and subscript digits: "³⁄₈"
+	StyleScript
+	// StyleDecimal writes a decimal number with the given precision: "0.375"
+	StyleDecimal
+	// StyleLaTeX writes LaTeX: "\frac{3}{8}"
+	StyleLaTeX
+	// StyleMathML writes presentation MathML:
+	// "<math><mfrac><mn>3</mn><mn>8</mn></mfrac></math>"
+	StyleMathML
+)
+
+// LaTeXCommand is the LaTeX command that is used for fractions
+type LaTeXCommand int
+
+const (
+	// LaTeXFrac uses \frac, which depends on the surrounding style
+	LaTeXFrac LaTeXCommand = iota
+	// LaTeXTFrac uses \tfrac, which always has the text style. Needs amsmath.
+	LaTeXTFrac
+	// LaTeXDFrac uses \dfrac, which always has the display style. Needs amsmath.
+	LaTeXDFrac
+)
+
+var latexCommands = map[LaTeXCommand]string{
+	LaTeXFrac:  `\frac`,
+	LaTeXTFrac: `\tfrac`,
+	LaTeXDFrac: `\dfrac`,
+}
+
+// FormatOptions decides how Format writes a fraction
+type FormatOptions struct {
+	Style Style
+	// Mixed writes fractions that are larger than 1 as mixed numbers,
+	// like "1 1/2". Ignored for StyleDecimal.
+	Mixed bool
+	// Precision is the number of digits after the decimal point, for
+	// StyleDecimal. The last digit is rounded half away from zero.
+	Precision int
+	// LaTeX is the command that is used for fractions, for StyleLaTeX
+	LaTeX LaTeXCommand
+}
+
+var (
+	superscriptDigits = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+	subscriptDigits   = []rune("₀₁₂₃₄₅₆₇₈₉")
+)
+
+// script writes the digits of n with the given digits, and a "-" for
+// negative numbers
+func script(n int64, digits []rune) string {
+	var sb strings.Builder
+	for _, r := range strconv.FormatInt(n, 10) {
+		if r == '-' {
+			sb.WriteRune(r)
+			continue
+		}
+		sb.WriteRune(digits[r-'0'])
+	}
+	return sb.String()
+}
+
+// markup writes the fraction as LaTeX or MathML. The sign is written in
+// front of the whole number, or in front of the fraction if there is no
+// whole number.
+func markup(x *Frac, opts FormatOptions) string {
+	negative := x.top < 0
+	top, bot, whole := uabs(x.top), uint64(x.bot), uint64(0)
+	if bot == 1 || (opts.Mixed && bot != 0) {
+		whole, top = top/bot, top%bot
+	}
+	latex := opts.Style == StyleLaTeX
+	var sb strings.Builder
+	if !latex {
+		sb.WriteString("<math>")
+	}
+	if negative {
+		if latex {
+			sb.WriteByte('-')
+		} else {
+			sb.WriteString("<mo>&#x2212;</mo>")
+		}
+	}
+	if whole != 0 || top == 0 {
+		if latex {
+			sb.WriteString(strconv.FormatUint(whole, 10))
+		} else {
+			fmt.Fprintf(&sb, "<mn>%d</mn>", whole)
+		}
+	}
+	if top != 0 {
+		if latex {
+			command, ok := latexCommands[opts.LaTeX]
+			if !ok {
+				command = latexCommands[LaTeXFrac]
+			}
+			fmt.Fprintf(&sb, "%s{%d}{%d}", command, top, bot)
+		} else {
+			if whole != 0 {
+				// Invisible plus, between the whole number and the fraction
+				sb.WriteString("<mo>&#x2064;</mo>")
+			}
+			fmt.Fprintf(&sb, "<mfrac><mn>%d</mn><mn>%d</mn></mfrac>", top, bot)
+		}
+	}
+	if !latex {
+		sb.WriteString("</math>")
+	}
+	return sb.String()
+}
+
+// Format returns the fraction as a string, written in the given style.
+// For example, 3/2 is "3/2", "1 1/2", "³⁄₂", "1½", "1.50" or
+// "1\tfrac{1}{2}".
+func Format(f *Frac, opts FormatOptions) string {
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		// Format the fraction as it is
+		x = f
+	}
+	switch {
+	case opts.Style == StyleDecimal && x.bot != 0:
+		precision := opts.Precision
+		if precision < 0 {
+			precision = 0
+		}
+		return x.Rat().FloatString(precision)
+	case opts.Style == StyleLaTeX || opts.Style == StyleMathML:
+		return markup(x, opts)
+	}
+	sign, whole := "", int64(0)
+	if opts.Mixed && x.bot != 0 && x.top/x.bot != 0 && x.top%x.bot != 0 {
+		whole = x.top / x.bot
+		x = &Frac{top: x.top % x.bot, bot: x.bot}
+		if x.top < 0 {
+			sign = "-"
+			whole, x.top = -whole, -x.top
+		}
+	}
+	var fraction string
+	switch {
+	case x.bot == 1:
+		fraction = strconv.FormatInt(x.top, 10)
+	case opts.Style == StyleASCII:
+		fraction = strconv.FormatInt(x.top, 10) + "/" + strconv.FormatInt(x.bot, 10)
+	case opts.Style == StyleScript:
+		fraction = script(x.top, superscriptDigits) + "⁄" + script(x.bot, subscriptDigits)
+	default:
+		fraction = x.String()
+	}
+	if whole == 0 {
+		return fraction
+	}
+	// A vulgar fraction character or superscript digits can follow directly
+	// after the whole number, but the other styles need a space
+	separator := " "
+	if opts.Style == StyleScript || utf8.RuneCountInString(fraction) == 1 {
+		separator = ""
+	}
+	return sign + strconv.FormatInt(whole, 10) + separator + fraction
+}
+
+// LaTeX returns the fraction as LaTeX, like "\frac{3}{8}" or "-\frac{1}{2}"
+func (f *Frac) LaTeX() string {
+	return Format(f, FormatOptions{Style: StyleLaTeX})
+}
+
+// MathML returns the fraction as presentation MathML, like
+// "<math><mfrac><mn>3</mn><mn>8</mn></mfrac></math>"
+func (f *Frac) MathML() string {
+	return Format(f, FormatOptions{Style: StyleMathML})
+}
+
+// Format implements fmt.Formatter, and supports these verbs:
+//
+//	%v and %s	the same as String: "⅜" or "3⁄16"
+//	%d	ASCII only: "3/8"
+//	%u	superscript and subscript digits: "³⁄₈"
+//	%q	a quoted string, like %s
+//	%#v	Go syntax: "num.MustNew(3, 8)"
+//	%e, %f, %g	a decimal number, like for float64
+//
+// The '#' flag writes mixed numbers for %s, %d and %u, like "1 1/2", and
+// the '+' flag adds a "+" to positive numbers. The width and the '-' flag
+// are supported by all verbs, and the precision is supported by %e, %f
+// and %g.
+func (f *Frac) Format(s fmt.State, verb rune) {
+	var opts FormatOptions
+	switch verb {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		if f.bot == 0 {
+			break
+		}
+		// Use enough precision to round correctly for all int64 fractions
+		new(big.Float).SetPrec(256).SetRat(f.Rat()).Format(s, verb)
+		return
+	case 'v':
+		if s.Flag('#') {
+			fmt.Fprintf(s, "num.MustNew(%d, %d)", f.top, f.bot)
+			return
+		}
+	case 's', 'q':
+		opts.Mixed = s.Flag('#')
+	case 'd':
+		opts.Style = StyleASCII
+		opts.Mixed = s.Flag('#')
+	case 'u':
+		opts.Style = StyleScript
+		opts.Mixed = s.Flag('#')
+	default:
+		fmt.Fprintf(s, "%%!%c(*num.Frac=%s)", verb, f)
+		return
+	}
+	str := Format(f, opts)
+	if s.Flag('+') && verb != 'v' && !strings.HasPrefix(str, "-") {
+		str = "+" + str
+	}
+	if verb == 'q' {
+		str = strconv.Quote(str)
+	}
+	if width, ok := s.Width(); ok {
+		if padding := width - utf8.RuneCountInString(str); padding > 0 {
+			if s.Flag('-') {
+				str += strings.Repeat(" ", padding)
+			} else {
+				str = strings.Repeat(" ", padding) + str
+			}
+		}
+	}
+	fmt.Fprint(s, str)
+}
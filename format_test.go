@@ -0,0 +1,155 @@
+package num
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		top, bot int64
+		opts     FormatOptions
+		want     string
+	}{
+		{3, 8, FormatOptions{}, "⅜"},
+		{3, 16, FormatOptions{}, "3⁄16"},
+		{3, 8, FormatOptions{Style: StyleASCII}, "3/8"},
+		{-3, 8, FormatOptions{Style: StyleASCII}, "-3/8"},
+		{3, 8, FormatOptions{Style: StyleScript}, "³⁄₈"},
+		{-13, 80, FormatOptions{Style: StyleScript}, "-¹³⁄₈₀"},
+		{3, 2, FormatOptions{Mixed: true}, "1½"},
+		{-3, 2, FormatOptions{Mixed: true}, "-1½"},
+		{35, 16, FormatOptions{Mixed: true}, "2 3⁄16"},
+		{3, 2, FormatOptions{Style: StyleASCII, Mixed: true}, "1 1/2"},
+		{-17, 8, FormatOptions{Style: StyleASCII, Mixed: true}, "-2 1/8"},
+		{-1, 8, FormatOptions{Style: StyleASCII, Mixed: true}, "-1/8"},
+		{4, 1, FormatOptions{Style: StyleASCII, Mixed: true}, "4"},
+		{11, 8, FormatOptions{Style: StyleScript, Mixed: true}, "1³⁄₈"},
+		{3, 8, FormatOptions{Style: StyleDecimal, Precision: 4}, "0.3750"},
+		{1, 3, FormatOptions{Style: StyleDecimal, Precision: 4}, "0.3333"},
+		{-1, 8, FormatOptions{Style: StyleDecimal, Precision: 2}, "-0.13"},
+		{3, 2, FormatOptions{Style: StyleDecimal}, "2"},
+	}
+	for _, test := range tests {
+		f := MustNew(test.top, test.bot)
+		s := Format(f, test.opts)
+		if s != test.want {
+			t.Errorf("%d/%d with %+v: expected %s, got %s", test.top, test.bot, test.opts, test.want, s)
+			continue
+		}
+		if test.opts.Style == StyleDecimal {
+			continue
+		}
+		// Every style except the decimal one must parse back to the same number
+		g, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if g.Cmp(f) != 0 {
+			t.Errorf("%s: expected %s, got %s", s, f, g)
+		}
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	f := MustNew(3, 8)
+	g := MustNew(-3, 2)
+	tests := []struct {
+		format string
+		a      interface{}
+		want   string
+	}{
+		{"%v", f, "⅜"},
+		{"%s", f, "⅜"},
+		{"%d", f, "3/8"},
+		{"%u", f, "³⁄₈"},
+		{"%q", f, `"⅜"`},
+		{"%#v", g, "num.MustNew(-3, 2)"},
+		{"%#s", g, "-1½"},
+		{"%#d", g, "-1 1/2"},
+		{"%+d", f, "+3/8"},
+		{"%+d", g, "-3/2"},
+		{"%6d|", f, "   3/8|"},
+		{"%-6d|", f, "3/8   |"},
+		{"%4s|", f, "   ⅜|"},
+		{"%.4f", f, "0.3750"},
+		{"%.2f", MustNew(1, 3), "0.33"},
+		{"%8.3f|", g, "  -1.500|"},
+		{"%.3e", MustNew(1, 3000), "3.333e-04"},
+		{"%g", f, "0.375"},
+		{"%x", f, "%!x(*num.Frac=⅜)"},
+		{"%v", []*Frac{f, g}, "[⅜ -3⁄2]"},
+	}
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, test.a); s != test.want {
+			t.Errorf("%s: expected %s, got %s", test.format, test.want, s)
+		}
+	}
+}
+
+func ExampleFormat() {
+	f := MustNew(11, 8)
+	fmt.Println(Format(f, FormatOptions{Style: StyleASCII}))
+	fmt.Println(Format(f, FormatOptions{Style: StyleASCII, Mixed: true}))
+	fmt.Println(Format(f, FormatOptions{Style: StyleScript}))
+	fmt.Println(Format(f, FormatOptions{Style: StyleDecimal, Precision: 2}))
+	fmt.Printf("%s %d %#d %u %.3f\n", f, f, f, f, f)
+	// Output:
+	// 11/8
+	// 1 3/8
+	// ¹¹⁄₈
+	// 1.38
+	// 11⁄8 11/8 1 3/8 ¹¹⁄₈ 1.375
+}
+
+func TestLaTeX(t *testing.T) {
+	tests := []struct {
+		top, bot int64
+		opts     FormatOptions
+		want     string
+	}{
+		{3, 8, FormatOptions{}, `\frac{3}{8}`},
+		{-3, 8, FormatOptions{}, `-\frac{3}{8}`},
+		{-3, 2, FormatOptions{}, `-\frac{3}{2}`},
+		{-3, 2, FormatOptions{Mixed: true}, `-1\frac{1}{2}`},
+		{-1, 2, FormatOptions{Mixed: true}, `-\frac{1}{2}`},
+		{7, 1, FormatOptions{}, `7`},
+		{-7, 1, FormatOptions{Mixed: true}, `-7`},
+		{0, 1, FormatOptions{}, `0`},
+		{3, 8, FormatOptions{LaTeX: LaTeXTFrac}, `\tfrac{3}{8}`},
+		{11, 8, FormatOptions{LaTeX: LaTeXDFrac, Mixed: true}, `1\dfrac{3}{8}`},
+		{-9223372036854775807, 2, FormatOptions{}, `-\frac{9223372036854775807}{2}`},
+	}
+	for _, test := range tests {
+		test.opts.Style = StyleLaTeX
+		if s := Format(MustNew(test.top, test.bot), test.opts); s != test.want {
+			t.Errorf("%d/%d with %+v: expected %s, got %s", test.top, test.bot, test.opts, test.want, s)
+		}
+	}
+	if s := MustNew(1, 3).LaTeX(); s != `\frac{1}{3}` {
+		t.Errorf("expected \\frac{1}{3}, got %s", s)
+	}
+}
+
+func TestMathML(t *testing.T) {
+	tests := []struct {
+		top, bot int64
+		mixed    bool
+		want     string
+	}{
+		{3, 8, false, "<math><mfrac><mn>3</mn><mn>8</mn></mfrac></math>"},
+		{-3, 8, false, "<math><mo>&#x2212;</mo><mfrac><mn>3</mn><mn>8</mn></mfrac></math>"},
+		{3, 2, true, "<math><mn>1</mn><mo>&#x2064;</mo><mfrac><mn>1</mn><mn>2</mn></mfrac></math>"},
+		{-3, 2, true, "<math><mo>&#x2212;</mo><mn>1</mn><mo>&#x2064;</mo><mfrac><mn>1</mn><mn>2</mn></mfrac></math>"},
+		{-5, 1, false, "<math><mo>&#x2212;</mo><mn>5</mn></math>"},
+		{0, 1, true, "<math><mn>0</mn></math>"},
+	}
+	for _, test := range tests {
+		s := Format(MustNew(test.top, test.bot), FormatOptions{Style: StyleMathML, Mixed: test.mixed})
+		if s != test.want {
+			t.Errorf("%d/%d: expected %s, got %s", test.top, test.bot, test.want, s)
+		}
+	}
+	if s := MustNew(3, 8).MathML(); s != tests[0].want {
+		t.Errorf("expected %s, got %s", tests[0].want, s)
+	}
+}
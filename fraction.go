@@ -0,0 +1,144 @@
+package num
+
+import (
+	"math/big"
+)
+
+// Fraction is an immutable fractional number. Every operation returns a new
+// value, so fractions can be shared between goroutines without copying.
+// A Fraction is always reduced, so two fractions are equal if and only if
+// they are equal with ==, and they can be used as map keys.
+// The zero value is 0.
+type Fraction struct {
+	num  int64 // numerator
+	denm int64 // denominator minus one, so that the zero value is 0/1
+}
+
+// NewFraction creates a new immutable fraction.
+// Takes a numerator and a denominator.
+// Returns ErrDivByZero if the denominator is 0, and ErrOverflow if the
+// sign can not be moved to the numerator.
+func NewFraction(num, dom int64) (Fraction, error) {
+	f, err := newChecked(num, dom)
+	if err != nil {
+		return Fraction{}, err
+	}
+	return f.Fraction(), nil
+}
+
+// MustNewFraction must create a new immutable fraction.
+// If it is not possible, no error will be returned and it will panic.
+func MustNewFraction(num, dom int64) Fraction {
+	x, err := NewFraction(num, dom)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+// FractionFromInt64 creates a new immutable fraction that is "N/1"
+func FractionFromInt64(num int64) Fraction {
+	return Fraction{num: num}
+}
+
+// Fraction returns the value of this fraction as an immutable Fraction
+func (f *Frac) Fraction() Fraction {
+	// Make sure that the fraction is fully reduced and that the sign is in
+	// the numerator, even if the fraction has been modified by hand
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		x = f
+	}
+	return Fraction{num: x.top, denm: x.bot - 1}
+}
+
+// Frac returns a new mutable Frac with the same value
+func (x Fraction) Frac() *Frac {
+	return &Frac{
+		top:           x.num,
+		bot:           x.Den(),
+		maxIterations: DefaultMaxIterations,
+	}
+}
+
+// Num returns the numerator
+func (x Fraction) Num() int64 {
+	return x.num
+}
+
+// Den returns the denominator, which is always positive
+func (x Fraction) Den() int64 {
+	return x.denm + 1
+}
+
+// result converts the result of a checked operation to a Fraction
+func result(f *Frac, err error) (Fraction, error) {
+	if err != nil {
+		return Fraction{}, err
+	}
+	return f.Fraction(), nil
+}
+
+// Add returns x + y, or ErrOverflow
+func (x Fraction) Add(y Fraction) (Fraction, error) {
+	return result(CheckedAdd(x.Frac(), y.Frac()))
+}
+
+// Sub returns x - y, or ErrOverflow
+func (x Fraction) Sub(y Fraction) (Fraction, error) {
+	return result(CheckedSub(x.Frac(), y.Frac()))
+}
+
+// Mul returns x * y, or ErrOverflow
+func (x Fraction) Mul(y Fraction) (Fraction, error) {
+	return result(CheckedMul(x.Frac(), y.Frac()))
+}
+
+// Div returns x / y, or ErrDivByZero or ErrOverflow
+func (x Fraction) Div(y Fraction) (Fraction, error) {
+	return result(CheckedDiv(x.Frac(), y.Frac()))
+}
+
+// Neg returns -x, or ErrOverflow
+func (x Fraction) Neg() (Fraction, error) {
+	return x.Mul(Fraction{num: -1})
+}
+
+// Abs returns the absolute value of x, or ErrOverflow
+func (x Fraction) Abs() (Fraction, error) {
+	if x.num < 0 {
+		return x.Neg()
+	}
+	return x, nil
+}
+
+// Inv returns 1 / x, or ErrDivByZero or ErrOverflow
+func (x Fraction) Inv() (Fraction, error) {
+	return Fraction{num: 1}.Div(x)
+}
+
+// Pow returns x raised to the power of n, which may be negative,
+// or ErrDivByZero or ErrOverflow
+func (x Fraction) Pow(n int) (Fraction, error) {
+	return result(Pow(x.Frac(), n))
+}
+
+// IsZero checks if x is 0
+func (x Fraction) IsZero() bool {
+	return x.num == 0
+}
+
+// Float64 returns x as a float64. Some precision may be lost.
+func (x Fraction) Float64() float64 {
+	return float64(x.num) / float64(x.Den())
+}
+
+// Rat returns x as a rational number (big.Rat)
+func (x Fraction) Rat() *big.Rat {
+	return big.NewRat(x.num, x.Den())
+}
+
+// String returns x as a string, in the same way as for Frac
+func (x Fraction) String() string {
+	return x.Frac().String()
+}
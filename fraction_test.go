@@ -0,0 +1,43 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFractionMapKey(t *testing.T) {
+	m := map[Fraction]string{}
+	m[MustNewFraction(1, 2)] = "half"
+	if m[MustNewFraction(-2, -4)] != "half" {
+		t.Error("Equal fractions should be equal map keys")
+	}
+	var zero Fraction
+	if zero != MustNewFraction(0, 7) || zero.String() != "0" {
+		t.Errorf("The zero value should be 0, got %s", zero)
+	}
+	if MustNew(6, 8).Fraction() != MustNewFraction(3, 4) {
+		t.Error("Converting from Frac should give an equal Fraction")
+	}
+}
+
+func TestFractionImmutable(t *testing.T) {
+	x := MustNewFraction(1, 3)
+	y := MustNewFraction(1, 6)
+	sum, err := x.Add(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != MustNewFraction(1, 2) || x != MustNewFraction(1, 3) {
+		t.Errorf("Expected ½ and an unchanged ⅓, got %s and %s", sum, x)
+	}
+	if _, err := x.Div(Fraction{}); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+	if _, err := FractionFromInt64(math.MinInt64).Neg(); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	inv, err := MustNewFraction(-3, 4).Inv()
+	if err != nil || inv != MustNewFraction(-4, 3) {
+		t.Errorf("Expected -4/3, got %s (%v)", inv, err)
+	}
+}
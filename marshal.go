@@ -0,0 +1,185 @@
+package num
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidEncoding is returned when a fraction can not be decoded
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// binaryVersion is the first byte of the binary encoding of a fraction
+const binaryVersion = 1
+
+// load sets this fraction to a decoded value. Fractions that have not been
+// created with New get the default maximum number of iterations.
+func (f *Frac) load(x *Frac) {
+	f.set(x)
+	if f.maxIterations == 0 {
+		f.maxIterations = DefaultMaxIterations
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler. The fraction is written
+// with ASCII characters only, like "3/8", "-1/2" or "4". The receiver is a
+// value, so that fractions that are not pointers are also marshaled.
+func (f Frac) MarshalText() ([]byte, error) {
+	return []byte(Format(&f, FormatOptions{Style: StyleASCII})), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, and accepts all the
+// strings that Parse accepts
+func (f *Frac) UnmarshalText(text []byte) error {
+	x, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	f.load(x)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The fraction is written as a
+// string, like "3/8". Use JSONObject to write {"num":3,"den":8} instead.
+func (f Frac) MarshalJSON() ([]byte, error) {
+	text, err := f.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A string that Parse accepts,
+// like "3/8" or "0.375", an object like {"num":3,"den":8} and a number
+// like 0.375 are all accepted, and give the exact value. null is ignored.
+func (f *Frac) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case bytes.HasPrefix(data, []byte(`"`)):
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return f.UnmarshalText([]byte(s))
+	case bytes.HasPrefix(data, []byte("{")):
+		var o JSONObject
+		if err := o.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		f.load(o.Frac)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	return f.UnmarshalText([]byte(n))
+}
+
+// JSONObject wraps a fraction, so that it is written to JSON as an object
+// with the numerator and the denominator, like {"num":3,"den":8}.
+// For example, as a struct field: Ratio num.JSONObject `json:"ratio"`
+type JSONObject struct {
+	*Frac
+}
+
+// jsonObject is the JSON representation of a fraction, for JSONObject
+type jsonObject struct {
+	Num *int64 `json:"num"`
+	Den *int64 `json:"den"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (o JSONObject) MarshalJSON() ([]byte, error) {
+	if o.Frac == nil {
+		return []byte("null"), nil
+	}
+	x, err := newChecked(o.top, o.bot)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonObject{Num: &x.top, Den: &x.bot})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The denominator may be left
+// out, for whole numbers.
+func (o *JSONObject) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var v jsonObject
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Num == nil {
+		return errors.New("missing numerator: " + string(data))
+	}
+	bot := int64(1)
+	if v.Den != nil {
+		bot = *v.Den
+	}
+	x, err := newChecked(*v.Num, bot)
+	if err != nil {
+		return err
+	}
+	if o.Frac == nil {
+		o.Frac = &Frac{}
+	}
+	o.Frac.load(x)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding starts
+// with a version byte, followed by the numerator, the denominator and the
+// maximum number of iterations, as variable length integers.
+func (f *Frac) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 1+3*binary.MaxVarintLen64)
+	buf[0] = binaryVersion
+	n := 1
+	for _, v := range []int64{f.top, f.bot, int64(f.maxIterations)} {
+		n += binary.PutVarint(buf[n:], v)
+	}
+	return buf[:n], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// Returns ErrInvalidEncoding if the data is not a fraction encoded with
+// MarshalBinary, and ErrDivByZero if the denominator is 0.
+func (f *Frac) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return ErrInvalidEncoding
+	}
+	data = data[1:]
+	var values [3]int64
+	for i := range values {
+		v, n := binary.Varint(data)
+		if n <= 0 {
+			return ErrInvalidEncoding
+		}
+		values[i], data = v, data[n:]
+	}
+	if len(data) != 0 || values[2] != int64(int(values[2])) {
+		return ErrInvalidEncoding
+	}
+	x, err := newChecked(values[0], values[1])
+	if err != nil {
+		return err
+	}
+	f.set(x)
+	f.maxIterations = int(values[2])
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, with the same encoding as
+// MarshalBinary
+func (f *Frac) GobEncode() ([]byte, error) {
+	return f.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder
+func (f *Frac) GobDecode(data []byte) error {
+	return f.UnmarshalBinary(data)
+}
@@ -0,0 +1,145 @@
+package num
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	type config struct {
+		Ratio  *Frac      `json:"ratio"`
+		Share  JSONObject `json:"share"`
+		Absent *Frac      `json:"absent"`
+		Value  Frac       `json:"value"`
+	}
+	c := config{Ratio: MustNew(-3, 8), Share: JSONObject{MustNew(6, 16)}, Value: *MustNew(3, 8)}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"ratio":"-3/8","share":{"num":3,"den":8},"absent":null,"value":"3/8"}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+	var d config
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Ratio.Cmp(c.Ratio) != 0 || d.Share.Cmp(c.Share.Frac) != 0 || d.Absent != nil || d.Value.Cmp(&c.Value) != 0 {
+		t.Errorf("expected %v, got %v", c, d)
+	}
+	// The maximum number of iterations must be usable after decoding
+	if d.Ratio.maxIterations != DefaultMaxIterations || d.Share.maxIterations != DefaultMaxIterations {
+		t.Errorf("expected %d iterations, got %d and %d", DefaultMaxIterations, d.Ratio.maxIterations, d.Share.maxIterations)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data       string
+		top, bot   int64
+		shouldFail bool
+	}{
+		{data: `"3/8"`, top: 3, bot: 8},
+		{data: `"1 1/2"`, top: 3, bot: 2},
+		{data: `"0.(3)"`, top: 1, bot: 3},
+		{data: `0.375`, top: 3, bot: 8},
+		{data: `-2`, top: -2, bot: 1},
+		{data: `1e-3`, top: 1, bot: 1000},
+		{data: `{"num":6,"den":-16}`, top: -3, bot: 8},
+		{data: `{"num":5}`, top: 5, bot: 1},
+		{data: ` "12.5%" `, top: 1, bot: 8},
+		{data: `{"num":1,"den":0}`, shouldFail: true},
+		{data: `{"den":2}`, shouldFail: true},
+		{data: `"x"`, shouldFail: true},
+		{data: `true`, shouldFail: true},
+		{data: `1e400`, shouldFail: true},
+	}
+	for _, test := range tests {
+		var f Frac
+		err := json.Unmarshal([]byte(test.data), &f)
+		if test.shouldFail {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", test.data, &f)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.data, err)
+			continue
+		}
+		if f.top != test.top || f.bot != test.bot {
+			t.Errorf("%s: expected %d/%d, got %d/%d", test.data, test.top, test.bot, f.top, f.bot)
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	type element struct {
+		Ratio *Frac `xml:"ratio,attr"`
+	}
+	data, err := xml.Marshal(element{MustNew(7, 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<element ratio="7/2"></element>`; string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+	var e element
+	if err := xml.Unmarshal(data, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Ratio.Cmp(MustNew(7, 2)) != 0 {
+		t.Errorf("expected 7/2, got %s", e.Ratio)
+	}
+	if err := new(Frac).UnmarshalText([]byte("3/0")); err == nil {
+		t.Error("expected an error for 3/0")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, f := range []*Frac{MustNew(3, 8), MustNew(-9223372036854775807, 9223372036854775806), NewZero()} {
+		f.SetMaxReduceIterations(17)
+		data, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var g Frac
+		if err := g.UnmarshalBinary(data); err != nil {
+			t.Errorf("%s: %v", f, err)
+			continue
+		}
+		if g != *f {
+			t.Errorf("expected %+v, got %+v", *f, g)
+		}
+	}
+	good, _ := MustNew(3, 8).MarshalBinary()
+	for _, data := range [][]byte{nil, {2, 6, 16, 0}, good[:2], append(good, 0), {1, 2, 0, 0}} {
+		if err := new(Frac).UnmarshalBinary(data); err == nil {
+			t.Errorf("%v: expected an error", data)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Name  string
+		Ratio *Frac
+	}
+	r := record{"pi", MustNew(355, 113)}
+	r.Ratio.SetMaxReduceIterations(5)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(r); err != nil {
+		t.Fatal(err)
+	}
+	var s record
+	if err := gob.NewDecoder(&buf).Decode(&s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != r.Name || *s.Ratio != *r.Ratio {
+		t.Errorf("expected %+v, got %+v", r, s)
+	}
+}
@@ -0,0 +1,346 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrSingular is returned by Matrix.Inverse and Matrix.Solve when the
+	// matrix has no inverse, or the system has no unique solution
+	ErrSingular = errors.New("singular matrix")
+
+	// ErrDimension is returned by the Matrix and Vector operations when the
+	// sizes of the operands do not match
+	ErrDimension = errors.New("dimension mismatch")
+)
+
+// Matrix is a matrix of fractions, where all the calculations are exact.
+// The operations return new matrices, and leave the matrix unchanged.
+type Matrix struct {
+	rows, cols int
+	data       []*Frac // row by row
+}
+
+// NewMatrix creates a new matrix from the given rows, which are copied.
+// Returns ErrDimension if there are no rows or columns, or if the rows do
+// not have the same length.
+func NewMatrix(rows [][]*Frac) (*Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, ErrDimension
+	}
+	m := newMatrix(len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return nil, ErrDimension
+		}
+		for j, f := range row {
+			x, err := newChecked(f.top, f.bot)
+			if err != nil {
+				return nil, err
+			}
+			m.data[i*m.cols+j] = x
+		}
+	}
+	return m, nil
+}
+
+// NewMatrixFromInt64 creates a new matrix of whole numbers from the given
+// rows. See NewMatrix.
+func NewMatrixFromInt64(rows [][]int64) (*Matrix, error) {
+	fracs := make([][]*Frac, len(rows))
+	for i, row := range rows {
+		fracs[i] = make([]*Frac, len(row))
+		for j, n := range row {
+			fracs[i][j] = NewFromInt64(n)
+		}
+	}
+	return NewMatrix(fracs)
+}
+
+// Identity returns the n×n identity matrix. Panics if n is less than 1,
+// since NewMatrix does not allow matrices without rows or columns either.
+func Identity(n int) *Matrix {
+	if n < 1 {
+		panic(fmt.Sprintf("num: size %d for the identity matrix is less than 1", n))
+	}
+	m := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = NewOne()
+	}
+	return m
+}
+
+// newMatrix returns a rows×cols matrix filled with zeros
+func newMatrix(rows, cols int) *Matrix {
+	m := &Matrix{rows: rows, cols: cols, data: make([]*Frac, rows*cols)}
+	for i := range m.data {
+		m.data[i] = NewZero()
+	}
+	return m
+}
+
+// Rows returns the number of rows
+func (m *Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+// index returns the index of the element at row i and column j in m.data.
+// Panics if i or j is out of range.
+func (m *Matrix) index(i, j int) int {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("num: index [%d, %d] out of range for a %d×%d matrix", i, j, m.rows, m.cols))
+	}
+	return i*m.cols + j
+}
+
+// At returns a copy of the element at row i and column j, counting from 0.
+// Panics if i or j is out of range.
+func (m *Matrix) At(i, j int) *Frac {
+	return m.data[m.index(i, j)].Copy()
+}
+
+// Set sets the element at row i and column j, counting from 0, to a copy
+// of the given fraction. Panics if i or j is out of range.
+func (m *Matrix) Set(i, j int, f *Frac) {
+	m.data[m.index(i, j)] = f.Copy()
+}
+
+// Copy creates a copy
+func (m *Matrix) Copy() *Matrix {
+	c := &Matrix{rows: m.rows, cols: m.cols, data: make([]*Frac, len(m.data))}
+	for i, f := range m.data {
+		c.data[i] = f.Copy()
+	}
+	return c
+}
+
+// Equal checks if the two matrices have the same size and elements
+func (m *Matrix) Equal(x *Matrix) bool {
+	if m.rows != x.rows || m.cols != x.cols {
+		return false
+	}
+	for i, f := range m.data {
+		if f.Cmp(x.data[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the matrix with one row per line, like "[1 ½]\n[0 1]"
+func (m *Matrix) String() string {
+	lines := make([]string, m.rows)
+	for i := range lines {
+		elements := make([]string, m.cols)
+		for j := range elements {
+			elements[j] = m.data[i*m.cols+j].String()
+		}
+		lines[i] = "[" + strings.Join(elements, " ") + "]"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Transpose returns the transpose of the matrix
+func (m *Matrix) Transpose() *Matrix {
+	t := &Matrix{rows: m.cols, cols: m.rows, data: make([]*Frac, len(m.data))}
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*t.cols+i] = m.data[i*m.cols+j].Copy()
+		}
+	}
+	return t
+}
+
+// mulSub returns a - b*c
+func mulSub(a, b, c *Frac) (*Frac, error) {
+	bc, err := CheckedMul(b, c)
+	if err != nil {
+		return nil, err
+	}
+	return CheckedSub(a, bc)
+}
+
+// Mul returns the matrix product m * x.
+// Returns ErrDimension if the number of columns of m is not the number of
+// rows of x, and ErrOverflow if an element does not fit.
+func (m *Matrix) Mul(x *Matrix) (*Matrix, error) {
+	if m.cols != x.rows {
+		return nil, ErrDimension
+	}
+	p := newMatrix(m.rows, x.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < x.cols; j++ {
+			sum := NewZero()
+			for k := 0; k < m.cols; k++ {
+				product, err := CheckedMul(m.data[i*m.cols+k], x.data[k*x.cols+j])
+				if err != nil {
+					return nil, err
+				}
+				if sum, err = CheckedAdd(sum, product); err != nil {
+					return nil, err
+				}
+			}
+			p.data[i*p.cols+j] = sum
+		}
+	}
+	return p, nil
+}
+
+// eliminate reduces the matrix to reduced row echelon form with Gauss–Jordan
+// elimination. Returns the reduced matrix, the columns of the pivots, and
+// the product of the pivots, with the sign changed for every row swap,
+// which is the determinant if the matrix is square and not singular.
+func (m *Matrix) eliminate() (*Matrix, []int, *Frac, error) {
+	var (
+		r      = m.Copy()
+		pivots []int
+		det    = NewOne()
+		err    error
+	)
+	row := func(i int) []*Frac {
+		return r.data[i*r.cols : (i+1)*r.cols]
+	}
+	for col := 0; col < r.cols && len(pivots) < r.rows; col++ {
+		p := len(pivots)
+		// Find a row with a non-zero element in this column
+		i := p
+		for i < r.rows && r.data[i*r.cols+col].IsZero() {
+			i++
+		}
+		if i == r.rows {
+			continue
+		}
+		if i != p {
+			for j, f := range row(i) {
+				row(p)[j], row(i)[j] = f, row(p)[j]
+			}
+			if det, err = CheckedMulInt(det, -1); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		// Divide the pivot row by the pivot
+		pivot := row(p)[col]
+		if det, err = CheckedMul(det, pivot); err != nil {
+			return nil, nil, nil, err
+		}
+		for j := col; j < r.cols; j++ {
+			if row(p)[j], err = CheckedDiv(row(p)[j], pivot); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		// Subtract the pivot row from the other rows
+		for k := 0; k < r.rows; k++ {
+			factor := row(k)[col]
+			if k == p || factor.IsZero() {
+				continue
+			}
+			for j := col; j < r.cols; j++ {
+				if row(k)[j], err = mulSub(row(k)[j], factor, row(p)[j]); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+		}
+		pivots = append(pivots, col)
+	}
+	return r, pivots, det, nil
+}
+
+// RREF returns the reduced row echelon form of the matrix.
+// Returns ErrOverflow if an element does not fit.
+func (m *Matrix) RREF() (*Matrix, error) {
+	r, _, _, err := m.eliminate()
+	return r, err
+}
+
+// Rank returns the rank of the matrix, the number of linearly independent
+// rows. Returns ErrOverflow if an element does not fit while calculating.
+func (m *Matrix) Rank() (int, error) {
+	_, pivots, _, err := m.eliminate()
+	return len(pivots), err
+}
+
+// Det returns the determinant of the matrix.
+// Returns ErrDimension if the matrix is not square, and ErrOverflow if an
+// element does not fit while calculating.
+func (m *Matrix) Det() (*Frac, error) {
+	if m.rows != m.cols {
+		return nil, ErrDimension
+	}
+	_, pivots, det, err := m.eliminate()
+	if err != nil {
+		return nil, err
+	}
+	if len(pivots) < m.rows {
+		return NewZero(), nil
+	}
+	return det, nil
+}
+
+// Inverse returns the inverse of the matrix.
+// Returns ErrDimension if the matrix is not square, ErrSingular if it has
+// no inverse, and ErrOverflow if an element does not fit.
+func (m *Matrix) Inverse() (*Matrix, error) {
+	if m.rows != m.cols {
+		return nil, ErrDimension
+	}
+	// Reduce [m | I] to [I | m^-1]
+	n := m.rows
+	a := newMatrix(n, 2*n)
+	for i := 0; i < n; i++ {
+		copy(a.data[i*2*n:], m.data[i*n:(i+1)*n])
+		a.data[i*2*n+n+i] = NewOne()
+	}
+	r, pivots, _, err := a.eliminate()
+	if err != nil {
+		return nil, err
+	}
+	if len(pivots) < n || pivots[n-1] != n-1 {
+		return nil, ErrSingular
+	}
+	inv := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		copy(inv.data[i*n:(i+1)*n], r.data[i*2*n+n:(i+1)*2*n])
+	}
+	return inv, nil
+}
+
+// Solve returns the solution x of m * x = b.
+// Returns ErrDimension if b does not have one element per row of m,
+// ErrSingular if there is no solution or more than one solution, and
+// ErrOverflow if an element does not fit.
+func (m *Matrix) Solve(b []*Frac) ([]*Frac, error) {
+	if len(b) != m.rows {
+		return nil, ErrDimension
+	}
+	// Reduce [m | b]
+	c := m.cols + 1
+	a := newMatrix(m.rows, c)
+	for i := 0; i < m.rows; i++ {
+		copy(a.data[i*c:], m.data[i*m.cols:(i+1)*m.cols])
+		x, err := newChecked(b[i].top, b[i].bot)
+		if err != nil {
+			return nil, err
+		}
+		a.data[i*c+m.cols] = x
+	}
+	r, pivots, _, err := a.eliminate()
+	if err != nil {
+		return nil, err
+	}
+	// Every variable needs a pivot, and b must not have one
+	if len(pivots) != m.cols || pivots[len(pivots)-1] != m.cols-1 {
+		return nil, ErrSingular
+	}
+	x := make([]*Frac, m.cols)
+	for i := range x {
+		x[i] = r.data[i*c+m.cols]
+	}
+	return x, nil
+}
@@ -0,0 +1,263 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// mustMatrix creates a matrix from fractions on the form "N/D" or "N"
+func mustMatrix(rows ...[]string) *Matrix {
+	fracs := make([][]*Frac, len(rows))
+	for i, row := range rows {
+		fracs[i] = make([]*Frac, len(row))
+		for j, s := range row {
+			f, err := Parse(s)
+			if err != nil {
+				panic(err)
+			}
+			fracs[i][j] = f
+		}
+	}
+	m, err := NewMatrix(fracs)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestNewMatrix(t *testing.T) {
+	if _, err := NewMatrixFromInt64([][]int64{{1, 2}, {3}}); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+	if _, err := NewMatrix(nil); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+	// The matrix must not change when the fractions it was created from change
+	f := MustNew(1, 2)
+	m, err := NewMatrix([][]*Frac{{f}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.MulInt(3)
+	m.At(0, 0).MulInt(5)
+	if m.String() != "[½]" {
+		t.Errorf("expected [½], got %s", m)
+	}
+	m.Set(0, 0, f)
+	if m.String() != "[3⁄2]" {
+		t.Errorf("expected [3⁄2], got %s", m)
+	}
+}
+
+func TestMatrixIndex(t *testing.T) {
+	m := Identity(2)
+	tests := []struct {
+		name string
+		f    func()
+		want string
+	}{
+		{"At(0, 2)", func() { m.At(0, 2) }, "num: index [0, 2] out of range for a 2×2 matrix"},
+		{"At(2, 0)", func() { m.At(2, 0) }, "num: index [2, 0] out of range for a 2×2 matrix"},
+		{"Set(-1, 0)", func() { m.Set(-1, 0, NewOne()) }, "num: index [-1, 0] out of range for a 2×2 matrix"},
+		{"Identity(-1)", func() { Identity(-1) }, "num: size -1 for the identity matrix is less than 1"},
+		// A 0×0 matrix would make Inverse and Solve index an empty slice
+		{"Identity(0).Inverse()", func() { Identity(0).Inverse() }, "num: size 0 for the identity matrix is less than 1"},
+		{"Identity(0).Solve(nil)", func() { Identity(0).Solve(nil) }, "num: size 0 for the identity matrix is less than 1"},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != test.want {
+					t.Errorf("%s: expected the panic %q, got %v", test.name, test.want, r)
+				}
+			}()
+			test.f()
+		}()
+	}
+	if m.String() != "[1 0]\n[0 1]" {
+		t.Errorf("expected the identity matrix, got\n%s", m)
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	a := mustMatrix([]string{"1", "1/2"}, []string{"0", "2"}, []string{"-1/3", "1"})
+	b := mustMatrix([]string{"2", "0", "1"}, []string{"1/4", "1", "0"})
+	want := mustMatrix(
+		[]string{"17/8", "1/2", "1"},
+		[]string{"1/2", "2", "0"},
+		[]string{"-5/12", "1", "-1/3"},
+	)
+	p, err := a.Mul(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(want) {
+		t.Errorf("expected\n%s\ngot\n%s", want, p)
+	}
+	if _, err := a.Mul(a); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+	if !a.Transpose().Transpose().Equal(a) || a.Transpose().Rows() != 2 || a.Transpose().At(1, 2).String() != "1" {
+		t.Errorf("wrong transpose:\n%s", a.Transpose())
+	}
+	big, _ := NewMatrixFromInt64([][]int64{{math.MaxInt64, 1}})
+	if _, err := big.Mul(big.Transpose()); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestMatrixDet(t *testing.T) {
+	tests := []struct {
+		m    *Matrix
+		want string
+	}{
+		{mustMatrix([]string{"1", "2"}, []string{"3", "4"}), "-2"},
+		{mustMatrix([]string{"0", "1"}, []string{"1", "0"}), "-1"},
+		{mustMatrix([]string{"1/2", "1/3"}, []string{"1/4", "1/5"}), "1⁄60"},
+		{mustMatrix([]string{"1", "2"}, []string{"2", "4"}), "0"},
+		{mustMatrix([]string{"2", "0", "1"}, []string{"1", "3", "2"}, []string{"1", "1", "2"}), "6"},
+		{Identity(4), "1"},
+	}
+	for _, test := range tests {
+		det, err := test.m.Det()
+		if err != nil {
+			t.Errorf("%s: %v", test.m, err)
+			continue
+		}
+		if det.String() != test.want {
+			t.Errorf("%s: expected %s, got %s", test.m, test.want, det)
+		}
+	}
+	if _, err := mustMatrix([]string{"1", "2"}).Det(); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+}
+
+func TestMatrixRREF(t *testing.T) {
+	m := mustMatrix(
+		[]string{"1", "2", "-1", "-4"},
+		[]string{"2", "3", "-1", "-11"},
+		[]string{"-2", "0", "-3", "22"},
+	)
+	want := mustMatrix(
+		[]string{"1", "0", "0", "-8"},
+		[]string{"0", "1", "0", "1"},
+		[]string{"0", "0", "1", "-2"},
+	)
+	r, err := m.RREF()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(want) {
+		t.Errorf("expected\n%s\ngot\n%s", want, r)
+	}
+	m = mustMatrix(
+		[]string{"0", "2", "4"},
+		[]string{"0", "1", "2"},
+		[]string{"0", "3", "7"},
+	)
+	want = mustMatrix(
+		[]string{"0", "1", "0"},
+		[]string{"0", "0", "1"},
+		[]string{"0", "0", "0"},
+	)
+	if r, err = m.RREF(); err != nil || !r.Equal(want) {
+		t.Errorf("expected\n%s\ngot\n%s, %v", want, r, err)
+	}
+	tests := []struct {
+		m    *Matrix
+		rank int
+	}{
+		{m, 2},
+		{Identity(3), 3},
+		{mustMatrix([]string{"0", "0"}), 0},
+		{mustMatrix([]string{"1", "2"}, []string{"1/2", "1"}, []string{"3", "6"}), 1},
+	}
+	for _, test := range tests {
+		if rank, err := test.m.Rank(); err != nil || rank != test.rank {
+			t.Errorf("%s: expected rank %d, got %d, %v", test.m, test.rank, rank, err)
+		}
+	}
+}
+
+func TestMatrixInverse(t *testing.T) {
+	m := mustMatrix([]string{"2", "0", "1"}, []string{"1", "3", "2"}, []string{"1", "1", "2"})
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := m.Mul(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(Identity(3)) {
+		t.Errorf("expected the identity matrix, got\n%s", p)
+	}
+	h := mustMatrix([]string{"1", "1/2", "1/3"}, []string{"1/2", "1/3", "1/4"}, []string{"1/3", "1/4", "1/5"})
+	inv, err = h.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mustMatrix([]string{"9", "-36", "30"}, []string{"-36", "192", "-180"}, []string{"30", "-180", "180"})
+	if !inv.Equal(want) {
+		t.Errorf("expected\n%s\ngot\n%s", want, inv)
+	}
+	if _, err := mustMatrix([]string{"1", "2"}, []string{"2", "4"}).Inverse(); err != ErrSingular {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+	if _, err := mustMatrix([]string{"1", "2"}).Inverse(); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+}
+
+func TestMatrixSolve(t *testing.T) {
+	m := mustMatrix(
+		[]string{"2", "1", "-1"},
+		[]string{"-3", "-1", "2"},
+		[]string{"-2", "1", "2"},
+	)
+	x, err := m.Solve([]*Frac{NewFromInt64(8), NewFromInt64(-11), NewFromInt64(-3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := fmt.Sprint(x); s != "[2 3 -1]" {
+		t.Errorf("expected [2 3 -1], got %s", s)
+	}
+	// More equations than variables, but they agree
+	m = mustMatrix([]string{"1", "1"}, []string{"1", "-1"}, []string{"2", "0"})
+	x, err = m.Solve([]*Frac{MustNew(1, 2), MustNew(1, 3), MustNew(5, 6)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := fmt.Sprint(x); s != "[5⁄12 1⁄12]" {
+		t.Errorf("expected [5⁄12 1⁄12], got %s", s)
+	}
+	// The equations disagree
+	if _, err := m.Solve([]*Frac{NewOne(), NewOne(), NewOne()}); err != ErrSingular {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+	// There are infinitely many solutions
+	m = mustMatrix([]string{"1", "2"}, []string{"2", "4"})
+	if _, err := m.Solve([]*Frac{NewOne(), MustNew(2, 1)}); err != ErrSingular {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+	if _, err := m.Solve([]*Frac{NewOne()}); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+}
+
+func ExampleMatrix_Inverse() {
+	m, err := NewMatrixFromInt64([][]int64{{4, 7}, {2, 6}})
+	if err != nil {
+		panic(err)
+	}
+	inv, err := m.Inverse()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(inv)
+	// Output:
+	// [⅗ -7⁄10]
+	// [-1⁄5 ⅖]
+}
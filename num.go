@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
-	"strconv"
-	"strings"
+	"math/bits"
 )
 
 const (
@@ -21,32 +20,33 @@ const (
 )
 
 type Frac struct {
-	top                 int64 // numerator
-	bot                 int64 // denominator
-	maxReduceIterations int   // maximum number of iterations for reducing the fraction
-	exactfloat          bool  // if the float64 representation will be exact
+	top           int64 // numerator
+	bot           int64 // denominator
+	maxIterations int   // maximum number of iterations for approximations, like NthRoot
 }
 
 var (
-	Zero = &Frac{0, 1, DefaultMaxIterations, true}
-	One  = &Frac{1, 1, DefaultMaxIterations, true}
+	// Zero and One are immutable values, so they can not be changed by
+	// accident. Use NewZero or NewOne, or Zero.Frac(), for a mutable copy.
+	Zero = Fraction{}
+	One  = Fraction{num: 1}
 
 	ErrDivByZero = errors.New("division by zero")
+	ErrOverflow  = errors.New("integer overflow")
 )
 
 // New creates a new fractional number.
 // Takes a numinator and a denominator.
-// The maximum number of iterations that should be used for reducing the
-// fraction during calculations is set to the default value.
+// The maximum number of iterations that should be used for approximations
+// is set to the default value.
 func New(num, dom int64) (*Frac, error) {
 	if dom == 0 {
 		return nil, ErrDivByZero
 	}
 	frac := &Frac{
-		top:                 num,
-		bot:                 dom,
-		maxReduceIterations: DefaultMaxIterations,
-		exactfloat:          true,
+		top:           num,
+		bot:           dom,
+		maxIterations: DefaultMaxIterations,
 	}
 	frac.reduce()
 	return frac, nil
@@ -65,15 +65,21 @@ func MustNew(num, dom int64) *Frac {
 // Try to convert a float to a fraction
 // Takes a float and a maximum number of iterations to find the fraction
 // The maximum number of iterations can be -1 to iterate as much as necessary
-// Returns a bool that is True if the maximum number of iterations has not been reached
+// If the maximum number of iterations is reached, the returned fraction is
+// only an approximation of the given float.
+// For an exact and fast conversion, see NewFromFloat64Exact.
+// Returns nil for NaN and ±Inf, which have no fraction. NewFromFloat64Exact
+// returns ErrNotFinite for those instead.
 func NewFromFloat64(f float64, maxIterations int) *Frac {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
 	// Thanks stackoverflow.com/questions/95727/how-to-convert-floats-to-human-readable-fractions
 	var (
 		num     int64   = 1
 		dom     int64   = 1
 		result  float64 = 1
 		counter int
-		exact   = true
 	)
 	for result != f {
 		if result < f {
@@ -84,19 +90,19 @@ func NewFromFloat64(f float64, maxIterations int) *Frac {
 		}
 		result = float64(num) / float64(dom)
 		if counter == maxIterations {
-			exact = false
 			break
 		}
 		counter++
 	}
 	// Will never divide on 0, so it's safe to ignore the error
 	frac, _ := New(num, dom)
-	frac.SetExact(exact)
 	return frac
 }
 
+// SetExact does nothing.
+//
+// Deprecated: ExactFloat64 is now derived from the value of the fraction.
 func (f *Frac) SetExact(exact bool) {
-	f.exactfloat = exact
 }
 
 // Create a new fraction that is "N/1"
@@ -113,39 +119,19 @@ func NewFromInt64(num int64) *Frac {
 	return frac
 }
 
-// NewZero returns a fraction that is "0/1"
+// NewZero returns a new fraction that is "0/1"
 func NewZero() *Frac {
-	return Zero
+	return Zero.Frac()
 }
 
-// Creates a new fraction from a string on the form "N/D", where N is the
-// numerator and D is the denominator. For example: "1/2" or "3/8".
-func NewFromString(exp string) (*Frac, error) {
-	var (
-		top int64
-		bot int64 = 1
-	)
-	if !strings.Contains(exp, "/") {
-		return &Frac{}, errors.New("This doesn't look like a fraction: " + exp)
-	}
-	parts := strings.Split(exp, "/")
-	if len(parts) != 2 {
-		return &Frac{}, errors.New("This doesn't look like a fraction: " + exp)
-	}
-	if value, err := strconv.Atoi(parts[0]); err == nil {
-		top = int64(value)
-	} else {
-		return &Frac{}, errors.New("Invalid numerator: " + parts[0])
-	}
-	if value, err := strconv.Atoi(parts[1]); err == nil {
-		bot = int64(value)
-	} else {
-		return &Frac{}, errors.New("Invalid denominator: " + parts[1])
-	}
-	return New(top, bot)
+// NewOne returns a new fraction that is "1/1"
+func NewOne() *Frac {
+	return One.Frac()
 }
 
-// Creates a new fraction from a rational number (big.Rat)
+// Creates a new fraction from a rational number (big.Rat).
+// The numerator and denominator are truncated if they do not fit in an
+// int64. Use NewBigFromRat to keep the exact value.
 func NewFromRat(rat *big.Rat) *Frac {
 	// Ignore error since *big.Rat denom can't be 0
 	frac, _ := New(rat.Num().Int64(), rat.Denom().Int64())
@@ -157,34 +143,21 @@ func (f *Frac) Rat() *big.Rat {
 	return big.NewRat(f.top, f.bot)
 }
 
-// Try reducing the fraction up to a maximum number of iterations which
-// is stored in the fraction itself
+// Reduce the fraction by dividing the numerator and the denominator by
+// their greatest common divisor, found with the Euclidean algorithm
 func (f *Frac) reduce() {
-	// Equal above and below are 1
-	if f.top == f.bot {
-		f.top = 1
-		f.bot = 1
-		f.exactfloat = true
+	// Leave fractions that are divided by zero as they are
+	if f.bot == 0 {
 		return
 	}
 	// If above is zero, discard the bottom
 	if f.top == 0 {
 		f.bot = 1
-		f.exactfloat = true
 		return
 	}
-	// Try ot reduce the fraction
-	var counter int
-	for trydiv := min(abs(f.top), abs(f.bot)); trydiv >= 2; trydiv-- {
-		if (f.top/trydiv)*trydiv == f.top && (f.bot/trydiv)*trydiv == f.bot {
-			f.top /= trydiv
-			f.bot /= trydiv
-		}
-		if counter == f.maxReduceIterations {
-			f.exactfloat = false
-			break
-		}
-		counter++
+	if g := int64(gcd(uabs(f.top), uabs(f.bot))); g != 1 {
+		f.top /= g
+		f.bot /= g
 	}
 	f.prettyNegative()
 }
@@ -194,23 +167,34 @@ func (f *Frac) Float64() float64 {
 	return float64(f.top) / float64(f.bot)
 }
 
+// ExactFloat64 checks if Float64 returns the exact value of the fraction.
+// This is the case when the denominator is a power of two and the
+// numerator fits in the 53 bit mantissa of a float64.
 func (f *Frac) ExactFloat64() bool {
-	return f.exactfloat
+	if f.bot <= 0 || f.bot&(f.bot-1) != 0 {
+		return false
+	}
+	m := uabs(f.top)
+	if m == 0 {
+		return true
+	}
+	return m>>uint(bits.TrailingZeros64(m)) <= 1<<53
 }
 
-// Return the fraction as an int, not rounded
+// Return the fraction as an int, not rounded, but truncated towards zero
 func (f *Frac) Int() int {
-	return int(f.Float64())
+	return int(f.RoundInt64(RoundTruncate))
 }
 
-// Return the fraction as an int, not rounded
+// Return the fraction as an int64, not rounded, but truncated towards zero
 func (f *Frac) Int64() int64 {
-	return int64(f.Float64())
+	return f.RoundInt64(RoundTruncate)
 }
 
-// Round of the fraction to an int
+// Round of the fraction to the nearest int. Halfway cases are rounded away
+// from zero, so that 5/2 is rounded to 3 and -5/2 to -3.
 func (f *Frac) Round() int {
-	return int(f.Float64() + 0.5)
+	return int(f.RoundInt64(RoundHalfAwayFromZero))
 }
 
 // Return the fraction as a string
@@ -396,31 +380,6 @@ func (f *Frac) IsZero() bool {
 	return f.top == 0
 }
 
-// Sqrt returns the square root of the number
-func Sqrt(f *Frac) *Frac {
-	// TODO: Use a numeric algorithm instead
-	return NewFromFloat64(math.Sqrt(float64(f.top))/math.Sqrt(float64(f.bot)), f.maxReduceIterations)
-}
-
-// Sin returns the sin of the number
-func Sin(f *Frac) *Frac {
-	// TODO: Use a numeric algorithm instead
-	return NewFromFloat64(math.Sin(f.Float64()), f.maxReduceIterations)
-}
-
-// Cos returns the sin of the number
-func Cos(f *Frac) *Frac {
-	// TODO: Use a numeric algorithm instead
-	return NewFromFloat64(math.Cos(f.Float64()), f.maxReduceIterations)
-}
-
-// Take the square root of this number
-func (f *Frac) Sqrt() {
-	// TODO: Use a numeric algorithm instead
-	x := math.Sqrt(float64(f.top)) / math.Sqrt(float64(f.bot))
-	f = NewFromFloat64(x, f.maxReduceIterations)
-}
-
 // Multiply this number by itself
 func (f *Frac) Square() {
 	f.top *= f.top
@@ -457,9 +416,99 @@ func Abs(f *Frac) *Frac {
 	return x
 }
 
-// Change the maximum number of iterations that should be used for reductions
-func (f *Frac) SetMaxReduceIterations(maxReduceIterations int) {
-	f.maxReduceIterations = maxReduceIterations
+// Negate the number. The numerator wraps around if it is the smallest
+// possible int64, use CheckedNeg to get ErrOverflow instead.
+func (f *Frac) Neg() {
+	f.top = -f.top
+}
+
+// Return the negated number. See the Neg method for the smallest possible
+// int64, and CheckedNeg.
+func Neg(f *Frac) *Frac {
+	x := f.Copy()
+	x.top = -x.top
+	return x
+}
+
+// Replace the number with its reciprocal, 1/f.
+// Returns ErrDivByZero if it is zero, and ErrOverflow if the reciprocal does
+// not fit. The number is left unchanged if an error is returned.
+func (f *Frac) Inv() error {
+	x, err := newChecked(f.bot, f.top)
+	if err != nil {
+		return err
+	}
+	f.set(x)
+	return nil
+}
+
+// Return the reciprocal, 1/f. Returns ErrDivByZero if the number is zero,
+// and ErrOverflow if the reciprocal does not fit.
+func Inv(f *Frac) (*Frac, error) {
+	x := f.Copy()
+	if err := x.Inv(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Raise the number to the power of n, which may be negative.
+// Returns ErrDivByZero for 0 to a negative power, and ErrOverflow if the
+// result does not fit. The number is left unchanged if an error is returned.
+func (f *Frac) Pow(n int) error {
+	x, err := Pow(f, n)
+	if err != nil {
+		return err
+	}
+	f.set(x)
+	return nil
+}
+
+// Return the number raised to the power of n, which may be negative.
+// Returns ErrDivByZero for 0 to a negative power, and ErrOverflow if the
+// result does not fit.
+func Pow(f *Frac, n int) (*Frac, error) {
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return nil, err
+	}
+	top, bot := x.top, x.bot
+	// This also works for the smallest possible int
+	e := uint(n)
+	if n < 0 {
+		if top == 0 {
+			return nil, ErrDivByZero
+		}
+		top, bot = bot, top
+		e = uint(-n)
+	}
+	// Exponentiation by squaring. The numerator and the denominator are
+	// coprime, so the result does not need to be reduced.
+	var (
+		rtop, rbot int64 = 1, 1
+		ok1, ok2         = true, true
+	)
+	for ; e > 0 && ok1 && ok2; e >>= 1 {
+		if e&1 == 1 {
+			rtop, ok1 = mul64(rtop, top)
+			rbot, ok2 = mul64(rbot, bot)
+		}
+		if e > 1 && ok1 && ok2 {
+			top, ok1 = mul64(top, top)
+			bot, ok2 = mul64(bot, bot)
+		}
+	}
+	if !ok1 || !ok2 {
+		return nil, ErrOverflow
+	}
+	return newChecked(rtop, rbot)
+}
+
+// Change the maximum number of iterations that should be used when
+// approximating a result from this fraction, for instance by NthRoot.
+// Reducing the fraction is always done in full, regardless of this number.
+func (f *Frac) SetMaxReduceIterations(maxIterations int) {
+	f.maxIterations = maxIterations
 }
 
 // Split up a fraction into an integer part, and the rest as another fraction
@@ -473,24 +522,23 @@ func (f *Frac) Splitup() (int64, *Frac) {
 // Copy creates a copy
 func (f *Frac) Copy() *Frac {
 	return &Frac{
-		top:                 f.top,
-		bot:                 f.bot,
-		maxReduceIterations: f.maxReduceIterations,
-		exactfloat:          f.exactfloat,
+		top:           f.top,
+		bot:           f.bot,
+		maxIterations: f.maxIterations,
 	}
 }
 
-// Quickly check if one fraction is larger than the other, by only multiplying and comparing
+// Check if one fraction is larger than the other, without overflowing
 func (f *Frac) GreaterThan(b *Frac) bool {
-	return f.top*b.bot > f.bot*b.top
+	return f.Cmp(b) > 0
 }
 
-// Quickly check if one fraction is less than the other, by only multiplying and comparing
+// Check if one fraction is less than the other, without overflowing
 func (f *Frac) LessThan(b *Frac) bool {
-	return f.top*b.bot < f.bot*b.top
+	return f.Cmp(b) < 0
 }
 
-// Quickly check if one fraction is equal to the other, by only multiplying and comparing
+// Check if one fraction is equal to the other, without overflowing
 func (f *Frac) Equal(b *Frac) bool {
-	return f.top*b.bot == f.bot*b.top
+	return f.Cmp(b) == 0
 }
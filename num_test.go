@@ -2,6 +2,7 @@ package num
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 )
@@ -83,6 +84,16 @@ func Test7(t *testing.T) {
 	fmt.Println("0.5 - 4 =", y.String(), y.Round(), y.Float64(), 0.5-4)
 }
 
+func TestNewFromFloat64NotFinite(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		for _, maxIterations := range []int{I, 10} {
+			if x := NewFromFloat64(f, maxIterations); x != nil {
+				t.Errorf("%v: expected nil, got %s", f, x)
+			}
+		}
+	}
+}
+
 func Test8(t *testing.T) {
 	x, _ := New(1, 3)
 	y, _ := New(1, 2)
@@ -129,16 +140,148 @@ func Test11(t *testing.T) {
 }
 
 func ExampleSqrt() {
-	x := NewFromInt(9)
-	fmt.Println(Sqrt(x).Float64())
+	x, err := Sqrt(NewFromInt(9))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(x.Float64())
 	// Output:
 	// 3
 }
 
-func ExampleUnicode() {
+func Example_unicode() {
 	//x, _ := New(3, 47)
 	x := NewFromFloat64(0.06382978723404255, L)
 	fmt.Println(x)
 	// Output:
 	// 3⁄47
 }
+
+func TestReduce(t *testing.T) {
+	f, _ := New(1000, 2000)
+	if f.String() != "½" || !f.ExactFloat64() {
+		t.Errorf("Should be reduced to an exact ½: %s %v", f, f.ExactFloat64())
+	}
+	f, _ = New(600851475143*2, 600851475143*3)
+	if f.top != 2 || f.bot != 3 {
+		t.Errorf("Not fully reduced: %s", f)
+	}
+	if f.ExactFloat64() {
+		t.Errorf("Should not be exact as a float64: %s", f)
+	}
+}
+
+// trialDivision reduces a fraction the way it was done before the
+// Euclidean algorithm was used, for comparison in the benchmarks
+func trialDivision(top, bot int64) (int64, int64) {
+	m := top
+	if bot < m {
+		m = bot
+	}
+	for trydiv := m; trydiv >= 2; trydiv-- {
+		if top%trydiv == 0 && bot%trydiv == 0 {
+			top /= trydiv
+			bot /= trydiv
+		}
+	}
+	return top, bot
+}
+
+func BenchmarkReduce(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		f := &Frac{top: 12345678, bot: 9876543}
+		f.reduce()
+	}
+}
+
+// BenchmarkReduceLarge uses the consecutive Fibonacci numbers F(92) and F(91),
+// the worst case for the Euclidean algorithm. Trial division would take
+// centuries for these, see BenchmarkReduceLargeTrialDivision instead.
+func BenchmarkReduceLarge(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		f := &Frac{top: 7540113804746346429, bot: 4660046610375530309}
+		f.reduce()
+	}
+}
+
+func BenchmarkReduceTrialDivision(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		trialDivision(12345678, 9876543)
+	}
+}
+
+// F(40) and F(39) are also consecutive Fibonacci numbers, but small enough for
+// trial division to finish in well under a second
+const fibTop, fibBot = 102334155, 63245986
+
+func BenchmarkReduceLargeBounded(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		f := &Frac{top: fibTop, bot: fibBot}
+		f.reduce()
+	}
+}
+
+func BenchmarkReduceLargeTrialDivision(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		trialDivision(fibTop, fibBot)
+	}
+}
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		f    *Frac
+		n    int
+		want string
+	}{
+		{MustNew(2, 3), 3, "8⁄27"},
+		{MustNew(-2, 3), -3, "-27⁄8"},
+		{MustNew(-1, 2), 0, "1"},
+		{NewZero(), 0, "1"},
+		{NewFromInt(-1), math.MinInt64, "1"},
+		{NewFromInt(2), 62, "4611686018427387904"},
+	}
+	for _, test := range tests {
+		x, err := Pow(test.f, test.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.String() != test.want {
+			t.Errorf("%s to the power of %d: expected %s, got %s", test.f, test.n, test.want, x)
+		}
+	}
+	if _, err := Pow(NewFromInt(2), 63); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	if _, err := Pow(MustNew(1, 3), 40); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	x := NewZero()
+	if err := x.Pow(-2); err != ErrDivByZero || !x.IsZero() {
+		t.Errorf("Expected ErrDivByZero and an unchanged zero, got %v and %s", err, x)
+	}
+}
+
+func TestInvNeg(t *testing.T) {
+	x := MustNew(-3, 4)
+	if err := x.Inv(); err != nil || x.String() != "-4⁄3" {
+		t.Errorf("Expected -4/3, got %s (%v)", x, err)
+	}
+	x.Neg()
+	if x.String() != "4⁄3" || Neg(x).String() != "-4⁄3" {
+		t.Errorf("Expected 4/3, got %s", x)
+	}
+	if _, err := Inv(NewZero()); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+	// The reciprocal of the smallest possible int64 does not fit
+	x = NewFromInt64(math.MinInt64)
+	if err := x.Inv(); err != ErrOverflow || x.String() != "-9223372036854775808" {
+		t.Errorf("Expected ErrOverflow and an unchanged number, got %v and %s", err, x)
+	}
+	if err := x.CheckedNeg(); err != ErrOverflow || x.String() != "-9223372036854775808" {
+		t.Errorf("Expected ErrOverflow and an unchanged number, got %v and %s", err, x)
+	}
+	if y, err := CheckedNeg(MustNew(2, -6)); err != nil || y.String() != "⅓" {
+		t.Errorf("Expected 1/3, got %s (%v)", y, err)
+	}
+}
@@ -0,0 +1,207 @@
+package num
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vulgarFractions maps the Unicode vulgar fraction characters to fractions
+var vulgarFractions = map[rune]string{
+	'¼': "1/4", '½': "1/2", '¾': "3/4",
+	'⅐': "1/7", '⅑': "1/9", '⅒': "1/10",
+	'⅓': "1/3", '⅔': "2/3",
+	'⅕': "1/5", '⅖': "2/5", '⅗': "3/5", '⅘': "4/5",
+	'⅙': "1/6", '⅚': "5/6",
+	'⅛': "1/8", '⅜': "3/8", '⅝': "5/8", '⅞': "7/8",
+	'↉': "0/3",
+	'⅟': "1/",
+}
+
+// scriptDigits maps superscript and subscript digits and signs to ASCII
+var scriptDigits = map[rune]rune{
+	'⁰': '0', '¹': '1', '²': '2', '³': '3', '⁴': '4',
+	'⁵': '5', '⁶': '6', '⁷': '7', '⁸': '8', '⁹': '9',
+	'₀': '0', '₁': '1', '₂': '2', '₃': '3', '₄': '4',
+	'₅': '5', '₆': '6', '₇': '7', '₈': '8', '₉': '9',
+	'⁺': '+', '⁻': '-', '₊': '+', '₋': '-',
+}
+
+// normalize replaces vulgar fraction characters, fraction slashes,
+// superscript and subscript digits and the minus sign with ASCII.
+// A space is inserted between a whole number and a fraction that follows
+// directly after it, so that "3½" and "1³⁄₈" become mixed numbers.
+func normalize(exp string) string {
+	var (
+		sb        strings.Builder
+		lastDigit bool // if the last character was an ordinary digit
+	)
+	for _, r := range exp {
+		if frac, ok := vulgarFractions[r]; ok {
+			if lastDigit {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(frac)
+			lastDigit = false
+			continue
+		}
+		if d, ok := scriptDigits[r]; ok {
+			if lastDigit && d >= '0' && d <= '9' {
+				sb.WriteByte(' ')
+			}
+			sb.WriteRune(d)
+			lastDigit = false
+			continue
+		}
+		switch r {
+		case '⁄', '∕':
+			r = '/'
+		case '−':
+			r = '-'
+		}
+		sb.WriteRune(r)
+		lastDigit = r >= '0' && r <= '9'
+	}
+	return sb.String()
+}
+
+// mixedNumber matches a whole number and a fraction, separated by spaces,
+// a hyphen or a plus sign. For example "1 3/4", "-2-1/8" or "5+1/2".
+var mixedNumber = regexp.MustCompile(`^([+-]?)(\d+)(?:\s+|\s*\+\s*|-)(\d+)\s*/\s*(\d+)$`)
+
+// Creates a new fraction from a string on the form "N/D", where N is the
+// numerator and D is the denominator. For example: "1/2" or "3/8".
+// Mixed numbers on the form "W N/D" are also accepted, where the whole
+// number and the fraction may also be separated by "-" or "+".
+// For example: "1 3/4", "-2 1/8" or "1-3/4".
+// Unicode vulgar fractions, the fraction slash (U+2044) and superscript and
+// subscript digits are accepted too, so that every string that is returned
+// by String can be parsed. For example: "½", "3½", "3⁄47" or "1³⁄₈".
+// Whole numbers, like "42", are also accepted.
+func NewFromString(exp string) (*Frac, error) {
+	var (
+		top int64
+		bot int64 = 1
+	)
+	exp = strings.TrimSpace(normalize(exp))
+	if !strings.Contains(exp, "/") {
+		// Whole numbers are also returned by String
+		if value, err := strconv.ParseInt(exp, 10, 64); err == nil {
+			return NewFromInt64(value), nil
+		}
+		return nil, errors.New("This doesn't look like a fraction: " + exp)
+	}
+	if mixedNumber.MatchString(exp) {
+		return newFromMixed(exp)
+	}
+	parts := strings.Split(exp, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("This doesn't look like a fraction: " + exp)
+	}
+	if value, err := strconv.Atoi(parts[0]); err == nil {
+		top = int64(value)
+	} else {
+		return nil, errors.New("Invalid numerator: " + parts[0])
+	}
+	if value, err := strconv.Atoi(parts[1]); err == nil {
+		bot = int64(value)
+	} else {
+		return nil, errors.New("Invalid denominator: " + parts[1])
+	}
+	return New(top, bot)
+}
+
+// newFromMixed creates a new fraction from a mixed number, like "-2 1/8".
+// The sign of the whole number applies to the fraction as well.
+func newFromMixed(exp string) (*Frac, error) {
+	m := mixedNumber.FindStringSubmatch(exp)
+	whole, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid whole number: " + m[2])
+	}
+	top, err := strconv.ParseInt(m[3], 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid numerator: " + m[3])
+	}
+	bot, err := strconv.ParseInt(m[4], 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid denominator: " + m[4])
+	}
+	if bot == 0 {
+		return nil, ErrDivByZero
+	}
+	if top >= bot {
+		return nil, errors.New("The fraction in a mixed number must be less than 1: " + exp)
+	}
+	// whole + top/bot = (whole*bot + top) / bot
+	x, ok1 := mul64(whole, bot)
+	x, ok2 := add64(x, top)
+	if !ok1 || !ok2 {
+		return nil, ErrOverflow
+	}
+	if m[1] == "-" {
+		x = -x
+	}
+	return New(x, bot)
+}
+
+// decimalNumber matches a decimal number, with an optional exponent.
+// A comma may be used as the decimal separator.
+var decimalNumber = regexp.MustCompile(`^[+-]?(\d+[.,]?\d*|[.,]\d+)([eE][+-]?\d+)?$`)
+
+// Parse creates a new fraction from a string, that may be on any of these
+// forms, and always gives the exact value:
+//
+//	Whole numbers, like "42" or "-7"
+//	Fractions, like "3/8", "-2 1/8", "3½" or "³⁄₈" (see NewFromString)
+//	Decimal numbers, like "0.125", "-.5" or "0,75"
+//	Scientific notation, like "1.5e-3" or "2E6"
+//	Repeating decimals, like "0.(142857)", "0.16̅" or "0.1666..."
+//	Percentages, like "12.5%", and per-mille, like "2.5‰"
+//
+// Returns ErrOverflow if the value does not fit in an int64 fraction.
+func Parse(s string) (*Frac, error) {
+	s = strings.TrimSpace(normalize(s))
+	for suffix, divisor := range map[string]int{"%": 100, "‰": 1000, "‱": 10000} {
+		if strings.HasSuffix(s, suffix) {
+			f, err := parseNumber(strings.TrimSuffix(s, suffix))
+			if err != nil {
+				return nil, err
+			}
+			return CheckedDivInt(f, divisor)
+		}
+	}
+	return parseNumber(s)
+}
+
+// parseNumber parses a whole number, a fraction, a decimal number or a
+// repeating decimal
+func parseNumber(s string) (*Frac, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		return NewFromString(s)
+	}
+	if isRepeating(s) {
+		return parseRepeating(s)
+	}
+	if !decimalNumber.MatchString(s) {
+		return nil, errors.New("This doesn't look like a number: " + s)
+	}
+	s = strings.Replace(s, ",", ".", 1)
+	// Avoid creating huge numbers for huge exponents, that can not fit anyway
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if strings.Trim(s[:i], "+-0.") == "" {
+			// Zero is zero, whatever the exponent is
+			s = s[:i]
+		} else if exp, err := strconv.Atoi(s[i+1:]); err != nil || exp > 1000 || exp < -1000 {
+			return nil, ErrOverflow
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, errors.New("This doesn't look like a number: " + s)
+	}
+	return fromRat(r)
+}
@@ -0,0 +1,127 @@
+package num
+
+import (
+	"testing"
+)
+
+func TestNewFromStringMixed(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"1 3/4", "7⁄4"},
+		{"-2 1/8", "-17⁄8"},
+		{"1-3/4", "7⁄4"},
+		{"-2-1/8", "-17⁄8"},
+		{"5+1/2", "11⁄2"},
+		{"5 + 1/2", "11⁄2"},
+		{"  0 1/3 ", "⅓"},
+		{"-3/7", "-3⁄7"},
+		{"6/-14", "-3⁄7"},
+	}
+	for _, test := range tests {
+		f, err := NewFromString(test.s)
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Errorf("%q: expected %s, got %s", test.s, test.want, f)
+		}
+	}
+	for _, s := range []string{"1 5/4", "1 3/0", "1 -3/4", "1  3/4/5", "one 3/4", "1 3/"} {
+		if _, err := NewFromString(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestNewFromStringUnicode(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"½", "½"},
+		{"3½", "7⁄2"},
+		{"-3½", "-7⁄2"},
+		{"2 ¾", "11⁄4"},
+		{"3⁄47", "3⁄47"},
+		{"³⁄₈", "⅜"},
+		{"1³⁄₈", "11⁄8"},
+		{"⁻³⁄₄₇", "-3⁄47"},
+		{"⅟₁₆", "1⁄16"},
+		{"⅟16", "1⁄16"},
+		{"↉", "0"},
+		{"−5∕10", "-1⁄2"},
+	}
+	for _, test := range tests {
+		f, err := NewFromString(test.s)
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Errorf("%q: expected %s, got %s", test.s, test.want, f)
+		}
+	}
+}
+
+func TestNewFromStringRoundTrip(t *testing.T) {
+	for top := int64(-40); top <= 40; top++ {
+		for bot := int64(1); bot <= 40; bot++ {
+			f := MustNew(top, bot)
+			g, err := NewFromString(f.String())
+			if err != nil {
+				t.Fatalf("%s: %v", f, err)
+			}
+			if !g.Equal(f) {
+				t.Errorf("%s was parsed as %s", f, g)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"42", "42"},
+		{"-7", "-7"},
+		{"3/8", "⅜"},
+		{"-2 1/8", "-17⁄8"},
+		{"3½", "7⁄2"},
+		{"0.125", "⅛"},
+		{"-.5", "-1⁄2"},
+		{"0,75", "3⁄4"},
+		{"1.5e-3", "3⁄2000"},
+		{"2E6", "2000000"},
+		{"12.5%", "⅛"},
+		{"-50%", "-1⁄2"},
+		{"2.5‰", "1⁄400"},
+		{"1/2%", "1⁄200"},
+		{" 0.1 ", "⅒"},
+		{"0e5000", "0"},
+		{"-0.00E-99999999999999999999", "0"},
+	}
+	for _, test := range tests {
+		f, err := Parse(test.s)
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Errorf("%q: expected %s, got %s", test.s, test.want, f)
+		}
+	}
+	for _, s := range []string{"", "abc", "1.2.3", "1e", "e5", "--1", "0x10", "1/2/3", "1/x", "1 3/0", "x%"} {
+		if f, err := Parse(s); err == nil || f != nil {
+			t.Errorf("%q: expected nil and an error, got %v, %v", s, f, err)
+		}
+	}
+	for _, s := range []string{"1e19", "0.1234567890123456789012", "1e-999999", "0.1e5000"} {
+		if f, err := Parse(s); err != ErrOverflow || f != nil {
+			t.Errorf("%q: expected nil and ErrOverflow, got %v, %v", s, f, err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package num
+
+import (
+	"sync"
+	"testing"
+)
+
+// Run with "go test -race" to check that the package level fractions can
+// be used from several goroutines at the same time
+func TestConcurrentZeroAndOne(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			z := NewZero()
+			z.AddInt(i + 3)
+			o := NewOne()
+			o.MulInt(i + 2)
+			if _, err := CheckedAdd(Zero.Frac(), One.Frac()); err != nil {
+				t.Error(err)
+			}
+			if _, err := One.Add(Zero); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if !NewZero().IsZero() || Zero.Num() != 0 || One.Num() != 1 {
+		t.Errorf("Zero and One should be unchanged: %s %s", Zero, One)
+	}
+}
+
+func TestConcurrentFraction(t *testing.T) {
+	shared := MustNewFraction(1, 3)
+	var wg sync.WaitGroup
+	results := make([]Fraction, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			x, err := shared.Mul(FractionFromInt64(int64(i)))
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = x
+		}(i)
+	}
+	wg.Wait()
+	for i, x := range results {
+		if x != MustNewFraction(int64(i), 3) {
+			t.Errorf("Expected %d/3, got %s", i, x)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package num
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+var (
+	// DefaultTolerance is the tolerance that is used by Sqrt, Sin and Cos,
+	// when the result can not be represented exactly. Sqrt multiplies it by
+	// the root when the root is smaller than 1, so that it is relative.
+	DefaultTolerance = Fraction{num: 1, denm: 1e9 - 1}
+
+	ErrNegativeRoot  = errors.New("even root of a negative number")
+	ErrMaxIterations = errors.New("maximum number of iterations reached")
+)
+
+// exactRoot returns the n-th root of x, and true, if it is an integer
+func exactRoot(x int64, n int) (int64, bool) {
+	if x < 0 {
+		return 0, false
+	}
+	// If x < 2^n, the root is smaller than 2, so only 0 and 1 are exact
+	if n >= 63 || x < 1<<uint(n) {
+		return x, x <= 1
+	}
+	guess := int64(math.Round(math.Pow(float64(x), 1/float64(n))))
+	bx := big.NewInt(x)
+	for r := guess - 1; r <= guess+1; r++ {
+		if r < 0 {
+			continue
+		}
+		p := new(big.Int).Exp(big.NewInt(r), big.NewInt(int64(n)), nil)
+		if p.Cmp(bx) == 0 {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// rootBounds finds lo and hi so that lo <= a^(1/n) <= hi and hi-lo <= width,
+// for a positive a. Newton's method is used, from above, and hi is rounded up
+// to a dyadic fraction q/2^prec after every step, to keep the numbers small.
+// The calculations are done with integers, since reducing big.Rat values
+// with huge powers in them is slow when n is large.
+func rootBounds(a *big.Rat, n int, width *big.Rat, maxIterations int) (lo, hi *big.Rat, err error) {
+	// Round hi up to a multiple of 1/2^prec, where 1/2^prec <= width/(4n)
+	prec := width.Denom().BitLen() - width.Num().BitLen() + bitLen(n) + 3
+	if prec < 0 {
+		prec = 0
+	}
+	scale := new(big.Int).Lsh(big.NewInt(1), uint(prec))
+	// a = num/den, and hi^n >= a if q^n * den >= num * 2^(prec*n)
+	num := new(big.Int).Lsh(a.Num(), uint(prec*n))
+	den := a.Denom()
+	isUpper := func(q *big.Int) bool {
+		return new(big.Int).Mul(pow(q, n), den).Cmp(num) >= 0
+	}
+	// Start from an upper bound that is close to the root
+	af, _ := a.Float64()
+	start := new(big.Rat).SetFloat64(math.Pow(af, 1/float64(n)) * (1 + 1e-9))
+	if start == nil || start.Sign() <= 0 {
+		start = new(big.Rat).SetInt64(1)
+	}
+	q, r := new(big.Int).DivMod(new(big.Int).Mul(start.Num(), scale), start.Denom(), new(big.Int))
+	if r.Sign() != 0 || q.Sign() == 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	for !isUpper(q) {
+		q.Lsh(q, 1)
+	}
+	bn := big.NewInt(int64(n))
+	bn1 := big.NewInt(int64(n - 1))
+	widthNum := new(big.Int).Mul(width.Num(), scale)
+	for counter := 0; ; counter++ {
+		// By the AM-GM inequality, a/hi^(n-1) is a lower bound if hi is an
+		// upper bound. lo*2^prec = num / (den * q^(n-1)), rounded both ways.
+		loQ, r := new(big.Int).DivMod(num, new(big.Int).Mul(den, pow(q, n-1)), new(big.Int))
+		loCeil := new(big.Int).Set(loQ)
+		if r.Sign() != 0 {
+			loCeil.Add(loCeil, big.NewInt(1))
+		}
+		// hi-lo <= width if (q-loQ) * width.Denom() <= width.Num() * 2^prec
+		diff := new(big.Int).Sub(q, loQ)
+		if diff.Mul(diff, width.Denom()).Cmp(widthNum) <= 0 {
+			return new(big.Rat).SetFrac(loQ, scale), new(big.Rat).SetFrac(q, scale), nil
+		}
+		if counter == maxIterations {
+			return nil, nil, ErrMaxIterations
+		}
+		// hi = ((n-1)*hi + lo) / n, rounded up so that it stays an upper bound
+		next := new(big.Int).Mul(bn1, q)
+		next.Add(next, loCeil)
+		q, r = next.DivMod(next, bn, new(big.Int))
+		if r.Sign() != 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+}
+
+// pow returns x to the power of n
+func pow(x *big.Int, n int) *big.Int {
+	return new(big.Int).Exp(x, big.NewInt(int64(n)), nil)
+}
+
+// bitLen returns the number of bits that are needed to represent n
+func bitLen(n int) int {
+	return big.NewInt(int64(n)).BitLen()
+}
+
+// NthRoot returns the n-th root of the number. The result is exact if the
+// numerator and the denominator are both n-th powers. If not, the fraction
+// with the smallest denominator that is within the given tolerance of the
+// root is returned, found with Newton's method.
+// Returns ErrNegativeRoot for even roots of negative numbers,
+// ErrInvalidTolerance if the tolerance is not positive, ErrOverflow if the
+// approximation does not fit and ErrMaxIterations if the maximum number of
+// iterations of f is reached.
+// The numbers in the calculation have about n times as many bits as the
+// tolerance needs, so the time grows faster than n. A root of 100000 takes
+// about half a second, and a root of a million takes many seconds.
+func NthRoot(f *Frac, n int, tolerance *Frac) (*Frac, error) {
+	var tol *big.Rat
+	if tolerance.Sign() > 0 {
+		tol = tolerance.Rat()
+	}
+	return nthRoot(f, n, tol, false, f.maxIterations)
+}
+
+// nthRoot returns the n-th root of f, see NthRoot. The tolerance is nil if
+// it is not positive. If relative is true, the tolerance is multiplied by
+// the root when the root is smaller than 1.
+func nthRoot(f *Frac, n int, tol *big.Rat, relative bool, maxIterations int) (*Frac, error) {
+	if n < 1 {
+		return nil, errors.New("the root must be at least 1")
+	}
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return nil, err
+	}
+	negative := x.top < 0
+	if negative {
+		if n%2 == 0 {
+			return nil, ErrNegativeRoot
+		}
+		if x.top == math.MinInt64 {
+			return nil, ErrOverflow
+		}
+		x.top = -x.top
+	}
+	if top, ok := exactRoot(x.top, n); ok {
+		if bot, ok := exactRoot(x.bot, n); ok {
+			if negative {
+				top = -top
+			}
+			return New(top, bot)
+		}
+	}
+	if tol == nil {
+		return nil, ErrInvalidTolerance
+	}
+	if relative {
+		if root := math.Pow(x.Float64(), 1/float64(n)); root < 1 {
+			tol = new(big.Rat).Mul(tol, new(big.Rat).SetFloat64(root))
+		}
+	}
+	width := new(big.Rat).Add(tol, tol)
+	lo, hi, err := rootBounds(x.Rat(), n, width, maxIterations)
+	if err != nil {
+		return nil, err
+	}
+	// Any fraction in [hi-tol, lo+tol] is within the tolerance of the root
+	r := simplestBetween(hi.Sub(hi, tol), lo.Add(lo, tol), false)
+	if negative {
+		r.Neg(r)
+	}
+	return fromRat(r)
+}
+
+// SqrtWithin returns the square root of the number. The result is exact if
+// the numerator and the denominator are both squares, if not it is within
+// the given tolerance. See NthRoot for the errors that may be returned.
+func SqrtWithin(f, tolerance *Frac) (*Frac, error) {
+	return NthRoot(f, 2, tolerance)
+}
+
+// Sqrt returns the square root of the number. The result is exact if the
+// numerator and the denominator are both squares, if not it is within
+// DefaultTolerance, or within DefaultTolerance times the root if the root is
+// smaller than 1. The maximum number of iterations of f is not used.
+// Returns ErrNegativeRoot if the number is negative, and ErrOverflow if the
+// approximation does not fit.
+func Sqrt(f *Frac) (*Frac, error) {
+	return nthRoot(f, 2, DefaultTolerance.Frac().Rat(), true, -1)
+}
+
+// Take the square root of this number. See Sqrt. The number is left unchanged
+// if an error is returned.
+func (f *Frac) Sqrt() error {
+	x, err := Sqrt(f)
+	if err != nil {
+		return err
+	}
+	f.set(x)
+	return nil
+}
@@ -0,0 +1,104 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestSqrtExact(t *testing.T) {
+	x := MustNew(9, 16)
+	if err := x.Sqrt(); err != nil || x.String() != "3⁄4" {
+		t.Errorf("Expected 3/4, got %s (%v)", x, err)
+	}
+	// The number is left unchanged on errors
+	y := NewFromInt(-4)
+	if err := y.Sqrt(); err != ErrNegativeRoot || y.String() != "-4" {
+		t.Errorf("Expected ErrNegativeRoot and -4, got %s (%v)", y, err)
+	}
+	z, err := NthRoot(MustNew(-8, 27), 3, NewZero())
+	if err != nil || z.String() != "-2⁄3" {
+		t.Errorf("Expected -2/3, got %s (%v)", z, err)
+	}
+	// Only 0 and 1 can have exact roots this large
+	z, err = NthRoot(MustNew(-1, 1), 1<<20+1, NewZero())
+	if err != nil || z.String() != "-1" {
+		t.Errorf("Expected -1, got %s (%v)", z, err)
+	}
+	if _, err := NthRoot(NewFromInt64(math.MaxInt64), 1<<20, NewZero()); err != ErrInvalidTolerance {
+		t.Errorf("Expected ErrInvalidTolerance, got %v", err)
+	}
+	if _, err := SqrtWithin(NewFromInt(-4), MustNew(1, 100)); err != ErrNegativeRoot {
+		t.Errorf("Expected ErrNegativeRoot, got %v", err)
+	}
+	if _, err := SqrtWithin(NewFromInt(2), NewZero()); err != ErrInvalidTolerance {
+		t.Errorf("Expected ErrInvalidTolerance, got %v", err)
+	}
+}
+
+func TestNthRootWithin(t *testing.T) {
+	tests := []struct {
+		f         *Frac
+		n         int
+		tolerance *Frac
+		want      string
+	}{
+		{NewFromInt(2), 2, MustNew(1, 100), "17⁄12"},
+		{NewFromInt(2), 2, MustNew(1, 1000000), "1393⁄985"},
+		{NewFromInt(-2), 3, MustNew(1, 1000), "-34⁄27"},
+		{NewFromInt(5), 10000, MustNew(1, 1000000), "6181⁄6180"},
+		{NewFromInt64(math.MaxInt64), 2, MustNew(1, 1000000000), "50848499371099⁄16743"},
+	}
+	for _, test := range tests {
+		x, err := NthRoot(test.f, test.n, test.tolerance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x.String() != test.want {
+			t.Errorf("Root %d of %s within %s: expected %s, got %s", test.n, test.f, test.tolerance, test.want, x)
+		}
+		// Check that square roots are within the tolerance, by comparing
+		// with the root as a big.Float
+		if test.n == 2 {
+			root := new(big.Float).SetPrec(200).Sqrt(new(big.Float).SetPrec(200).SetRat(test.f.Rat()))
+			diff := new(big.Float).Sub(new(big.Float).SetRat(x.Rat()), root)
+			if diff.Abs(diff).Cmp(new(big.Float).SetRat(test.tolerance.Rat())) > 0 {
+				t.Errorf("Root %d of %s: %s is not within %s", test.n, test.f, x, test.tolerance)
+			}
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	tests := []*Frac{
+		NewFromInt(2),
+		MustNew(1, 2e18),
+		MustNew(3, math.MaxInt64),
+		NewFromInt64(math.MaxInt64),
+	}
+	for _, f := range tests {
+		// The maximum number of iterations is not used by Sqrt
+		f.SetMaxReduceIterations(0)
+		x, err := Sqrt(f)
+		if err != nil {
+			t.Errorf("%s: %v", f, err)
+			continue
+		}
+		// Check that the relative error is within DefaultTolerance, by
+		// comparing with the root as a big.Float
+		root := new(big.Float).SetPrec(200).Sqrt(new(big.Float).SetPrec(200).SetRat(f.Rat()))
+		diff := new(big.Float).SetPrec(200).Sub(new(big.Float).SetPrec(200).SetRat(x.Rat()), root)
+		diff.Quo(diff.Abs(diff), root)
+		if x.IsZero() || diff.Cmp(new(big.Float).SetRat(DefaultTolerance.Frac().Rat())) > 0 {
+			t.Errorf("%s: %s is not within %s of the root", f, x, DefaultTolerance)
+		}
+	}
+}
+
+func ExampleSqrtWithin() {
+	x, _ := SqrtWithin(NewFromInt(2), MustNew(1, 10000))
+	fmt.Println(x, x.Float64())
+	// Output:
+	// 99⁄70 1.4142857142857144
+}
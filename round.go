@@ -0,0 +1,98 @@
+package num
+
+import (
+	"errors"
+)
+
+// RoundingMode decides how a fraction is rounded to an integer
+type RoundingMode int
+
+const (
+	// RoundFloor rounds towards negative infinity
+	RoundFloor RoundingMode = iota
+	// RoundCeil rounds towards positive infinity
+	RoundCeil
+	// RoundTruncate rounds towards zero
+	RoundTruncate
+	// RoundHalfUp rounds to the nearest integer, and halfway cases up
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest integer, and halfway cases to the
+	// nearest even integer. Also known as banker's rounding.
+	RoundHalfEven
+	// RoundHalfAwayFromZero rounds to the nearest integer, and halfway cases
+	// away from zero
+	RoundHalfAwayFromZero
+)
+
+// RoundInt64 rounds the fraction to an int64 with the given rounding mode.
+// The result is exact, since it is calculated from the numerator and the
+// denominator, without going through a float64.
+func (f *Frac) RoundInt64(mode RoundingMode) int64 {
+	top, bot := f.top, f.bot
+	if bot < 0 {
+		// Only possible for fractions that are not yet reduced
+		top, bot = -top, -bot
+	}
+	// The fraction is q + r/bot, where 0 <= r/bot < 1
+	q, r := floorDiv(top, bot)
+	if r == 0 {
+		return q
+	}
+	switch mode {
+	case RoundFloor:
+		return q
+	case RoundCeil:
+		return q + 1
+	case RoundTruncate:
+		if q < 0 {
+			return q + 1
+		}
+		return q
+	}
+	// Compare r/bot with 1/2, without overflowing
+	switch rest := bot - r; {
+	case r < rest:
+		return q
+	case r > rest:
+		return q + 1
+	}
+	switch mode {
+	case RoundHalfEven:
+		if q%2 == 0 {
+			return q
+		}
+	case RoundHalfAwayFromZero:
+		if q < 0 {
+			return q
+		}
+	}
+	return q + 1
+}
+
+// RoundTo rounds the fraction to a multiple of 1/n, with the given rounding
+// mode. For example, RoundTo(f, 16, RoundHalfEven) rounds to the nearest 1/16.
+// Returns ErrDivByZero if n is 0, and ErrOverflow if f*n does not fit.
+func RoundTo(f *Frac, n int64, mode RoundingMode) (*Frac, error) {
+	if n < 0 {
+		return nil, errors.New("can only round to a multiple of 1/n for positive n")
+	}
+	if n == 0 {
+		return nil, ErrDivByZero
+	}
+	x, err := CheckedMul(f, NewFromInt64(n))
+	if err != nil {
+		return nil, err
+	}
+	return New(x.RoundInt64(mode), n)
+}
+
+// RoundTo rounds the fraction to a multiple of 1/n, with the given rounding
+// mode. The fraction is left unchanged if an error is returned.
+func (f *Frac) RoundTo(n int64, mode RoundingMode) error {
+	x, err := RoundTo(f, n, mode)
+	if err != nil {
+		return err
+	}
+	f.set(x)
+	return nil
+}
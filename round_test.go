@@ -0,0 +1,52 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundInt64(t *testing.T) {
+	modes := []RoundingMode{RoundFloor, RoundCeil, RoundTruncate, RoundHalfUp, RoundHalfEven, RoundHalfAwayFromZero}
+	tests := []struct {
+		f    *Frac
+		want [6]int64
+	}{
+		{MustNew(5, 2), [6]int64{2, 3, 2, 3, 2, 3}},
+		{MustNew(-5, 2), [6]int64{-3, -2, -2, -2, -2, -3}},
+		{MustNew(7, 2), [6]int64{3, 4, 3, 4, 4, 4}},
+		{MustNew(-7, 2), [6]int64{-4, -3, -3, -3, -4, -4}},
+		{MustNew(7, 3), [6]int64{2, 3, 2, 2, 2, 2}},
+		{MustNew(-8, 3), [6]int64{-3, -2, -2, -3, -3, -3}},
+		{NewFromInt(-4), [6]int64{-4, -4, -4, -4, -4, -4}},
+		{MustNew(math.MaxInt64, 2), [6]int64{1<<62 - 1, 1 << 62, 1<<62 - 1, 1 << 62, 1 << 62, 1 << 62}},
+	}
+	for _, test := range tests {
+		for i, mode := range modes {
+			if got := test.f.RoundInt64(mode); got != test.want[i] {
+				t.Errorf("%s with mode %d: expected %d, got %d", test.f, mode, test.want[i], got)
+			}
+		}
+	}
+	if MustNew(-5, 2).Round() != -3 {
+		t.Errorf("-5/2 should be rounded to -3")
+	}
+	if NewFromInt64(math.MaxInt64).Int64() != math.MaxInt64 {
+		t.Errorf("Int64 should be exact")
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	f := MustNew(1, 3)
+	if err := f.RoundTo(16, RoundHalfEven); err != nil {
+		t.Fatal(err)
+	}
+	if f.String() != "5⁄16" {
+		t.Errorf("Expected 5/16, got %s", f)
+	}
+	if _, err := RoundTo(f, 0, RoundFloor); err != ErrDivByZero {
+		t.Errorf("Expected ErrDivByZero, got %v", err)
+	}
+	if _, err := RoundTo(NewFromInt64(math.MaxInt64), 2, RoundFloor); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+}
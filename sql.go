@@ -0,0 +1,68 @@
+package num
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Scan implements sql.Scanner, so that fractions can be read from a
+// database. Integers, floats and text in any of the forms that Parse
+// accepts, like "3/8" or "0.375" from a numeric or decimal column, are
+// supported. NULL gives an error, so use a *Frac pointer to read columns
+// that may be NULL.
+// Floats are converted exactly, see NewFromFloat64Exact. Most floats that
+// are not dyadic fractions, like 0.0001, do not fit exactly, and are then
+// converted from the shortest decimal that gives the same float instead,
+// which is 1/10000 for 0.0001. Floats that do not fit either way, like
+// 1e300, give ErrOverflow.
+func (f *Frac) Scan(src interface{}) error {
+	var (
+		x   *Frac
+		err error
+	)
+	switch v := src.(type) {
+	case int64:
+		x = NewFromInt64(v)
+	case float64:
+		x, err = NewFromFloat64Exact(v)
+		if err == ErrOverflow {
+			x, err = Parse(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	case []byte:
+		x, err = Parse(string(v))
+	case string:
+		x, err = Parse(v)
+	case nil:
+		return errors.New("can not scan NULL into a fraction")
+	default:
+		return fmt.Errorf("can not scan %T into a fraction", src)
+	}
+	if err != nil {
+		return err
+	}
+	f.load(x)
+	return nil
+}
+
+// Value implements driver.Valuer, so that fractions can be written to a
+// database. Whole numbers are written as integers, and other fractions as
+// text, like "3/8". A nil fraction is written as NULL.
+func (f *Frac) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return nil, err
+	}
+	if x.bot == 1 {
+		return x.top, nil
+	}
+	text, err := x.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
@@ -0,0 +1,183 @@
+package num
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a database driver with a single table with a single
+// column, that is shared by all connections with the same name.
+// Every statement with arguments inserts the arguments as rows, and every
+// statement without arguments selects all the rows.
+type fakeDriver struct {
+	mut    sync.Mutex
+	tables map[string][]driver.Value
+}
+
+type fakeConn struct {
+	d    *fakeDriver
+	name string
+}
+
+type fakeStmt struct {
+	c *fakeConn
+}
+
+type fakeRows struct {
+	values []driver.Value
+}
+
+var fake = &fakeDriver{tables: make(map[string][]driver.Value)}
+
+func init() {
+	sql.Register("fake", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d, name}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.d.mut.Lock()
+	defer s.c.d.mut.Unlock()
+	s.c.d.tables[s.c.name] = append(s.c.d.tables[s.c.name], args...)
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.c.d.mut.Lock()
+	defer s.c.d.mut.Unlock()
+	values := append([]driver.Value(nil), s.c.d.tables[s.c.name]...)
+	return &fakeRows{values}, nil
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"ratio"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func TestValue(t *testing.T) {
+	db, err := sql.Open("fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var absent *Frac
+	for _, f := range []*Frac{MustNew(-3, 8), MustNew(8, 2), absent} {
+		if _, err := db.Exec("INSERT INTO ratios VALUES (?)", f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []driver.Value{"-3/8", int64(4), nil}
+	got := fake.tables[t.Name()]
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %#v, got %#v", want[i], got[i])
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	fake.tables[t.Name()] = []driver.Value{
+		int64(5),
+		0.375,
+		0.0001,
+		1.5e-7,
+		[]byte("3/8"),
+		"0.125",
+		"-1 1/2",
+		"12.5%",
+		nil,
+	}
+	want := []string{"5", "⅜", "1⁄10000", "3⁄20000000", "⅜", "⅛", "-3⁄2", "⅛", "<nil>"}
+	db, err := sql.Open("fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT ratio FROM ratios")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		// A pointer is needed for NULL
+		var f *Frac
+		if err := rows.Scan(&f); err != nil {
+			t.Fatal(err)
+		}
+		if f == nil {
+			got = append(got, "<nil>")
+			continue
+		}
+		if f.maxIterations != DefaultMaxIterations {
+			t.Errorf("expected %d iterations, got %d", DefaultMaxIterations, f.maxIterations)
+		}
+		got = append(got, f.String())
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %s, got %s", want[i], got[i])
+		}
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	for _, src := range []interface{}{nil, true, "abc", 1.5e300, []byte("1/0")} {
+		var f Frac
+		if err := f.Scan(src); err == nil {
+			t.Errorf("%v: expected an error, got %s", src, &f)
+		}
+	}
+	// Floats that do not fit exactly are not errors
+	for _, src := range []interface{}{0.0001, 0.00012, 1.5e-7} {
+		var f Frac
+		if err := f.Scan(src); err != nil || f.Float64() != src {
+			t.Errorf("%v: expected the same value, got %s, %v", src, &f, err)
+		}
+	}
+}
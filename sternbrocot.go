@@ -0,0 +1,140 @@
+package num
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxSternBrocotPath is the longest path that SternBrocotPath will return
+const MaxSternBrocotPath = 1 << 20
+
+// ErrEmptyInterval is returned by SimplestBetween when there are no
+// fractions strictly between the bounds
+var ErrEmptyInterval = errors.New("empty interval")
+
+// Farey returns the Farey sequence of order n, which is every reduced
+// fraction from 0 to 1 with a denominator that is at most n, in order.
+// For example, the Farey sequence of order 3 is 0, 1/3, 1/2, 2/3, 1.
+// Returns ErrDomain if n is smaller than 1.
+func Farey(n int) ([]*Frac, error) {
+	if n < 1 {
+		return nil, ErrDomain
+	}
+	var (
+		order      = int64(n)
+		a, b, c, d = int64(0), int64(1), int64(1), order
+		seq        = []*Frac{NewZero()}
+	)
+	// Every fraction follows from the two before it
+	for c <= order {
+		k := (order + b) / d
+		a, b, c, d = c, d, k*c-a, k*d-b
+		seq = append(seq, &Frac{top: a, bot: b, maxIterations: DefaultMaxIterations})
+	}
+	return seq, nil
+}
+
+// Mediant returns the mediant of two fractions, (a+c)/(b+d) for a/b and c/d,
+// which is always between them. The fractions are reduced first.
+// Returns ErrOverflow if the mediant does not fit.
+func Mediant(x, y *Frac) (*Frac, error) {
+	a, err := newChecked(x.top, x.bot)
+	if err != nil {
+		return nil, err
+	}
+	b, err := newChecked(y.top, y.bot)
+	if err != nil {
+		return nil, err
+	}
+	top, ok1 := add64(a.top, b.top)
+	bot, ok2 := add64(a.bot, b.bot)
+	if !ok1 || !ok2 {
+		return nil, ErrOverflow
+	}
+	return newChecked(top, bot)
+}
+
+// SternBrocotPath returns the path from the root of the Stern–Brocot tree,
+// 1/1, down to the given positive fraction, as a string of "L" and "R".
+// For example, the path to 3/5 is "LRL", and the path to 1 is "".
+// Returns ErrDomain if the fraction is not positive, and ErrOverflow if the
+// path is longer than MaxSternBrocotPath.
+func SternBrocotPath(f *Frac) (string, error) {
+	x, err := newChecked(f.top, f.bot)
+	if err != nil {
+		return "", err
+	}
+	if x.top <= 0 {
+		return "", ErrDomain
+	}
+	// The path follows from the continued fraction [a0; a1, ..., an]:
+	// a0 times R, a1 times L, a2 times R and so on, but an-1 times at the end
+	terms := x.ContinuedFraction()
+	terms[len(terms)-1]--
+	var length int64
+	for _, a := range terms {
+		if length += a; length > MaxSternBrocotPath {
+			return "", ErrOverflow
+		}
+	}
+	var sb strings.Builder
+	sb.Grow(int(length))
+	for i, a := range terms {
+		step := "R"
+		if i%2 == 1 {
+			step = "L"
+		}
+		sb.WriteString(strings.Repeat(step, int(a)))
+	}
+	return sb.String(), nil
+}
+
+// NewFromSternBrocotPath creates a new fraction by following the given path
+// of "L" and "R" down from the root of the Stern–Brocot tree, 1/1.
+// Returns ErrOverflow if the fraction does not fit.
+func NewFromSternBrocotPath(path string) (*Frac, error) {
+	// The fraction is the mediant of the left and the right bound, which
+	// start as 0/1 and 1/0
+	var (
+		ltop, lbot int64 = 0, 1
+		rtop, rbot int64 = 1, 0
+	)
+	for _, step := range path {
+		top, ok1 := add64(ltop, rtop)
+		bot, ok2 := add64(lbot, rbot)
+		if !ok1 || !ok2 {
+			return nil, ErrOverflow
+		}
+		switch step {
+		case 'L':
+			rtop, rbot = top, bot
+		case 'R':
+			ltop, lbot = top, bot
+		default:
+			return nil, errors.New("This is not a path of L and R: " + path)
+		}
+	}
+	top, ok1 := add64(ltop, rtop)
+	bot, ok2 := add64(lbot, rbot)
+	if !ok1 || !ok2 {
+		return nil, ErrOverflow
+	}
+	// The fractions in the Stern–Brocot tree are already reduced
+	return &Frac{top: top, bot: bot, maxIterations: DefaultMaxIterations}, nil
+}
+
+// SimplestBetween returns the simplest fraction that is strictly between
+// the two given fractions, which is the fraction with the smallest
+// denominator, and the one that is closest to the root of the Stern–Brocot
+// tree. The bounds may be given in any order.
+// Returns ErrEmptyInterval if the bounds are equal.
+func SimplestBetween(a, b *Frac) (*Frac, error) {
+	lo, hi := a.Rat(), b.Rat()
+	switch lo.Cmp(hi) {
+	case 0:
+		return nil, ErrEmptyInterval
+	case 1:
+		lo, hi = hi, lo
+	}
+	return fromRat(simplestBetween(lo, hi, true))
+}
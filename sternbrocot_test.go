@@ -0,0 +1,132 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFarey(t *testing.T) {
+	seq, err := Farey(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[0 ⅕ ¼ ⅓ ⅖ ½ ⅗ ⅔ 3⁄4 ⅘ 1]"
+	if s := fmt.Sprint(seq); s != want {
+		t.Errorf("expected %s, got %s", want, s)
+	}
+	// The length of the Farey sequence of order n is 1 + the sum of Euler's
+	// totient function up to n
+	lengths := []int{2, 3, 5, 7, 11, 13, 19, 23, 29, 33}
+	for n, length := range lengths {
+		seq, err := Farey(n + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(seq) != length {
+			t.Errorf("order %d: expected %d fractions, got %d", n+1, length, len(seq))
+		}
+		if !IsSorted(seq) {
+			t.Errorf("order %d: not sorted: %v", n+1, seq)
+		}
+	}
+	if _, err := Farey(0); err != ErrDomain {
+		t.Errorf("expected ErrDomain, got %v", err)
+	}
+}
+
+func TestMediant(t *testing.T) {
+	m, err := Mediant(MustNew(1, 3), MustNew(1, 2))
+	if err != nil || m.String() != "⅖" {
+		t.Errorf("expected ⅖, got %v, %v", m, err)
+	}
+	// The fractions are reduced first
+	m, err = Mediant(MustNew(2, 4), MustNew(-1, -1))
+	if err != nil || m.String() != "⅔" {
+		t.Errorf("expected ⅔, got %v, %v", m, err)
+	}
+	if _, err := Mediant(MustNew(math.MaxInt64, 2), MustNew(1, 3)); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestSternBrocotPath(t *testing.T) {
+	tests := []struct {
+		top, bot int64
+		path     string
+	}{
+		{1, 1, ""},
+		{1, 2, "L"},
+		{2, 1, "R"},
+		{3, 5, "LRL"},
+		{3, 2, "RL"},
+		{22, 7, "RRRLLLLLL"},
+		{1, 5, "LLLL"},
+	}
+	for _, test := range tests {
+		f := MustNew(test.top, test.bot)
+		path, err := SternBrocotPath(f)
+		if err != nil {
+			t.Errorf("%s: %v", f, err)
+			continue
+		}
+		if path != test.path {
+			t.Errorf("%s: expected %q, got %q", f, test.path, path)
+		}
+		g, err := NewFromSternBrocotPath(path)
+		if err != nil {
+			t.Errorf("%q: %v", path, err)
+			continue
+		}
+		if *g != *f {
+			t.Errorf("%q: expected %s, got %s", path, f, g)
+		}
+	}
+	for _, f := range []*Frac{NewZero(), MustNew(-1, 2)} {
+		if _, err := SternBrocotPath(f); err != ErrDomain {
+			t.Errorf("%s: expected ErrDomain, got %v", f, err)
+		}
+	}
+	if _, err := SternBrocotPath(MustNew(1, math.MaxInt64)); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	if _, err := NewFromSternBrocotPath("LRX"); err == nil {
+		t.Error("expected an error for LRX")
+	}
+	if _, err := NewFromSternBrocotPath(strings.Repeat("LR", 50)); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestSimplestBetween(t *testing.T) {
+	tests := []struct {
+		a, b Fraction
+		want string
+	}{
+		{MustNewFraction(1, 3), MustNewFraction(1, 2), "⅖"},
+		{MustNewFraction(1, 2), MustNewFraction(1, 3), "⅖"},
+		{MustNewFraction(0, 1), MustNewFraction(1, 1), "½"},
+		{MustNewFraction(1, 1), MustNewFraction(3, 1), "2"},
+		{MustNewFraction(1, 1), MustNewFraction(2, 1), "3⁄2"},
+		{MustNewFraction(2, 1), MustNewFraction(9, 4), "11⁄5"},
+		{MustNewFraction(-1, 1), MustNewFraction(1, 1), "0"},
+		{MustNewFraction(-1, 1), MustNewFraction(0, 1), "-1⁄2"},
+		{MustNewFraction(-7, 3), MustNewFraction(-9, 4), "-16⁄7"},
+		{MustNewFraction(314, 100), MustNewFraction(315, 100), "22⁄7"},
+		{MustNewFraction(3, 1), MustNewFraction(22, 7), "25⁄8"},
+	}
+	for _, test := range tests {
+		f, err := SimplestBetween(test.a.Frac(), test.b.Frac())
+		if err != nil {
+			t.Errorf("%s, %s: %v", test.a, test.b, err)
+			continue
+		}
+		if f.String() != test.want {
+			t.Errorf("%s, %s: expected %s, got %s", test.a, test.b, test.want, f)
+		}
+	}
+	if _, err := SimplestBetween(MustNew(1, 2), MustNew(2, 4)); err != ErrEmptyInterval {
+		t.Errorf("expected ErrEmptyInterval, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package num
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+// ErrDomain is returned when a function is not defined for the given number
+var ErrDomain = errors.New("argument out of domain")
+
+// guardBits is the number of extra bits of precision that are used when
+// evaluating series, on top of what is needed for the tolerance. This more
+// than covers the rounding errors of the individual operations.
+const guardBits = 64
+
+// tolBits returns the number of bits that are needed after the point, to
+// represent a number with the given tolerance
+func tolBits(tol *big.Rat) uint {
+	if b := tol.Denom().BitLen() - tol.Num().BitLen() + 1; b > 0 {
+		return uint(b)
+	}
+	return 0
+}
+
+// newFloat returns a new big.Float with the given precision
+func newFloat(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec)
+}
+
+// bitLen64 returns the number of bits in the absolute value of x
+func bitLen64(x int64) int {
+	return bits.Len64(uabs(x))
+}
+
+// exponent returns the binary exponent of x, or a large negative number for 0
+func exponent(x *big.Float) int {
+	if x.Sign() == 0 {
+		return -1 << 30
+	}
+	return x.MantExp(nil)
+}
+
+// atanhSeries returns atanh(x) = x + x^3/3 + x^5/5 + ..., for small |x|
+func atanhSeries(x *big.Float, prec uint) *big.Float {
+	return arcSeries(x, prec, false)
+}
+
+// atanSeries returns atan(x) = x - x^3/3 + x^5/5 - ..., for small |x|
+func atanSeries(x *big.Float, prec uint) *big.Float {
+	return arcSeries(x, prec, true)
+}
+
+// arcSeries sums x^(2k+1)/(2k+1), with alternating signs if alternate is
+// true, until the terms are smaller than 2^-prec
+func arcSeries(x *big.Float, prec uint, alternate bool) *big.Float {
+	var (
+		sum  = newFloat(prec).Set(x)
+		pow  = newFloat(prec).Set(x)
+		x2   = newFloat(prec).Mul(x, x)
+		term = newFloat(prec)
+	)
+	for k := int64(1); ; k++ {
+		pow.Mul(pow, x2)
+		term.Quo(pow, newFloat(prec).SetInt64(2*k+1))
+		if exponent(term) < -int(prec) {
+			return sum
+		}
+		if alternate && k%2 == 1 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+	}
+}
+
+// piFloat returns π, by using Machin's formula
+func piFloat(prec uint) *big.Float {
+	a := atanSeries(newFloat(prec).Quo(newFloat(prec).SetInt64(1), newFloat(prec).SetInt64(5)), prec)
+	b := atanSeries(newFloat(prec).Quo(newFloat(prec).SetInt64(1), newFloat(prec).SetInt64(239)), prec)
+	a.Mul(a, newFloat(prec).SetInt64(16))
+	b.Mul(b, newFloat(prec).SetInt64(4))
+	return a.Sub(a, b)
+}
+
+// ln2Float returns the natural logarithm of 2, as 2*atanh(1/3)
+func ln2Float(prec uint) *big.Float {
+	x := atanhSeries(newFloat(prec).Quo(newFloat(prec).SetInt64(1), newFloat(prec).SetInt64(3)), prec)
+	return x.Mul(x, newFloat(prec).SetInt64(2))
+}
+
+// sinCosFloat returns the sine and the cosine of x. The argument is reduced
+// to [-π/4, π/4] before the Taylor series are used.
+func sinCosFloat(x *big.Float, prec uint) (*big.Float, *big.Float) {
+	// Reducing large arguments needs more bits of π
+	wprec := prec
+	if e := exponent(x); e > 0 {
+		wprec += uint(e)
+	}
+	halfPi := piFloat(wprec)
+	halfPi.SetMantExp(halfPi, -1)
+	q := newFloat(wprec).Quo(x, halfPi)
+	k, _ := roundFloat(q).Int(nil)
+	r := newFloat(wprec).SetInt(k)
+	r.Sub(x, r.Mul(r, halfPi))
+	r = newFloat(prec).Set(r)
+	// Taylor series for sin(r) and cos(r)
+	var (
+		sin  = newFloat(prec).Set(r)
+		cos  = newFloat(prec).SetInt64(1)
+		term = newFloat(prec).SetInt64(1)
+		r2   = newFloat(prec).Mul(r, r)
+	)
+	for n := int64(1); exponent(term) >= -int(prec); n++ {
+		// term = (-1)^n r^(2n) / (2n)!
+		term.Mul(term, r2)
+		term.Quo(term, newFloat(prec).SetInt64((2*n-1)*(2*n)))
+		term.Neg(term)
+		cos.Add(cos, term)
+		// sin gets the term (-1)^n r^(2n+1) / (2n+1)!
+		s := newFloat(prec).Mul(term, r)
+		s.Quo(s, newFloat(prec).SetInt64(2*n+1))
+		sin.Add(sin, s)
+	}
+	// Turn the result around, depending on the quadrant
+	switch new(big.Int).Mod(k, big.NewInt(4)).Int64() {
+	case 1:
+		return cos, sin.Neg(sin)
+	case 2:
+		return sin.Neg(sin), cos.Neg(cos)
+	case 3:
+		return cos.Neg(cos), sin
+	}
+	return sin, cos
+}
+
+// roundFloat rounds x to the nearest integer
+func roundFloat(x *big.Float) *big.Float {
+	half := big.NewFloat(0.5)
+	if x.Sign() < 0 {
+		half.Neg(half)
+	}
+	i, _ := newFloat(x.Prec()).Add(x, half).Int(nil)
+	return new(big.Float).SetInt(i)
+}
+
+// atanFloat returns the arctangent of x
+func atanFloat(x *big.Float, prec uint) *big.Float {
+	if x.Sign() < 0 {
+		r := atanFloat(newFloat(prec).Neg(x), prec)
+		return r.Neg(r)
+	}
+	one := newFloat(prec).SetInt64(1)
+	if x.Cmp(one) > 0 {
+		// atan(x) = π/2 - atan(1/x)
+		r := atanFloat(newFloat(prec).Quo(one, x), prec)
+		halfPi := piFloat(prec)
+		halfPi.SetMantExp(halfPi, -1)
+		return halfPi.Sub(halfPi, r)
+	}
+	// atan(x) = 2*atan(x/(1+sqrt(1+x^2))), done twice, gives |x| < 0.2
+	y := newFloat(prec).Set(x)
+	for i := 0; i < 2; i++ {
+		d := newFloat(prec).Mul(y, y)
+		d.Add(d, one)
+		d.Sqrt(d)
+		d.Add(d, one)
+		y.Quo(y, d)
+	}
+	r := atanSeries(y, prec)
+	return r.SetMantExp(r, 2)
+}
+
+// expFloat returns e^x, by writing x as n*ln(2) + r, where |r| <= ln(2)/2
+func expFloat(x *big.Float, prec uint) *big.Float {
+	ln2 := ln2Float(prec)
+	n, _ := roundFloat(newFloat(prec).Quo(x, ln2)).Int64()
+	r := newFloat(prec).Mul(newFloat(prec).SetInt64(n), ln2)
+	r.Sub(x, r)
+	var (
+		sum  = newFloat(prec).SetInt64(1)
+		term = newFloat(prec).SetInt64(1)
+	)
+	for k := int64(1); exponent(term) >= -int(prec); k++ {
+		term.Mul(term, r)
+		term.Quo(term, newFloat(prec).SetInt64(k))
+		sum.Add(sum, term)
+	}
+	return sum.SetMantExp(sum, int(n))
+}
+
+// logFloat returns the natural logarithm of a positive x, by writing x as
+// m*2^e, where 0.5 <= m < 1, and log(m) as 2*atanh((m-1)/(m+1))
+func logFloat(x *big.Float, prec uint) *big.Float {
+	m := newFloat(prec)
+	e := x.MantExp(m)
+	one := newFloat(prec).SetInt64(1)
+	z := newFloat(prec).Sub(m, one)
+	z.Quo(z, newFloat(prec).Add(m, one))
+	r := atanhSeries(z, prec)
+	r.SetMantExp(r, 1)
+	ln2 := ln2Float(prec)
+	return r.Add(r, ln2.Mul(ln2, newFloat(prec).SetInt64(int64(e))))
+}
+
+// within returns the fraction with the smallest denominator that is within
+// the tolerance of fn(f). The function is evaluated with enough bits of
+// precision for the given magnitude, and for the tolerance, so that the
+// result is within half of the tolerance. The simplest fraction within the
+// other half is then returned.
+func within(f, tolerance *Frac, magnitude uint, fn func(x *big.Float, prec uint) *big.Float) (*Frac, error) {
+	if tolerance.Sign() <= 0 {
+		return nil, ErrInvalidTolerance
+	}
+	tol := tolerance.Rat()
+	prec := magnitude + tolBits(tol) + guardBits
+	// The argument needs more bits if it is large
+	xprec := prec
+	if b := bitLen64(f.top) - bitLen64(f.bot); b > 0 {
+		xprec += uint(b)
+	}
+	vr, _ := fn(newFloat(xprec).SetRat(f.Rat()), prec).Rat(nil)
+	half := new(big.Rat).Mul(tol, big.NewRat(1, 2))
+	return fromRat(simplestBetween(new(big.Rat).Sub(vr, half), new(big.Rat).Add(vr, half), false))
+}
+
+// SinWithin returns the sine of the number, within the given tolerance.
+// Returns ErrInvalidTolerance if the tolerance is not positive.
+func SinWithin(f, tolerance *Frac) (*Frac, error) {
+	return within(f, tolerance, 1, func(x *big.Float, prec uint) *big.Float {
+		sin, _ := sinCosFloat(x, prec)
+		return sin
+	})
+}
+
+// CosWithin returns the cosine of the number, within the given tolerance.
+// Returns ErrInvalidTolerance if the tolerance is not positive.
+func CosWithin(f, tolerance *Frac) (*Frac, error) {
+	return within(f, tolerance, 1, func(x *big.Float, prec uint) *big.Float {
+		_, cos := sinCosFloat(x, prec)
+		return cos
+	})
+}
+
+// TanWithin returns the tangent of the number, within the given tolerance.
+// Returns ErrInvalidTolerance if the tolerance is not positive, and
+// ErrOverflow if the result does not fit.
+func TanWithin(f, tolerance *Frac) (*Frac, error) {
+	return within(f, tolerance, 64, func(x *big.Float, prec uint) *big.Float {
+		sin, cos := sinCosFloat(x, prec)
+		if e := exponent(cos); e < 0 {
+			// The error of the tangent grows with 1/cos^2 close to the poles
+			sin, cos = sinCosFloat(x, prec+uint(-2*e))
+		}
+		return sin.Quo(sin, cos)
+	})
+}
+
+// AtanWithin returns the arctangent of the number, within the given
+// tolerance. Returns ErrInvalidTolerance if the tolerance is not positive.
+func AtanWithin(f, tolerance *Frac) (*Frac, error) {
+	return within(f, tolerance, 2, func(x *big.Float, prec uint) *big.Float {
+		return atanFloat(x, prec)
+	})
+}
+
+// ExpWithin returns e to the power of the number, within the given
+// tolerance. Returns ErrInvalidTolerance if the tolerance is not positive,
+// and ErrOverflow if the result does not fit.
+func ExpWithin(f, tolerance *Frac) (*Frac, error) {
+	if f.Cmp(NewFromInt(44)) > 0 {
+		// e^44 is larger than the largest int64
+		return nil, ErrOverflow
+	}
+	if tolerance.Sign() > 0 {
+		// If e^f < 2^-(bits+1), where 0.7 > ln(2), the result is 0
+		limit := NewFromInt64(-int64(tolBits(tolerance.Rat()) + 1))
+		limit.Mul(MustNew(7, 10))
+		if f.Cmp(limit) < 0 {
+			return NewZero(), nil
+		}
+	}
+	return within(f, tolerance, 64, func(x *big.Float, prec uint) *big.Float {
+		return expFloat(x, prec)
+	})
+}
+
+// LogWithin returns the natural logarithm of the number, within the given
+// tolerance. Returns ErrDomain if the number is not positive, and
+// ErrInvalidTolerance if the tolerance is not positive.
+func LogWithin(f, tolerance *Frac) (*Frac, error) {
+	if f.Sign() <= 0 {
+		return nil, ErrDomain
+	}
+	return within(f, tolerance, 6, func(x *big.Float, prec uint) *big.Float {
+		return logFloat(x, prec)
+	})
+}
+
+// Sin returns the sine of the number, within DefaultTolerance
+func Sin(f *Frac) *Frac {
+	// The tolerance is positive and the result is between -1 and 1, so
+	// no error can be returned
+	x, _ := SinWithin(f, DefaultTolerance.Frac())
+	return x
+}
+
+// Cos returns the cosine of the number, within DefaultTolerance
+func Cos(f *Frac) *Frac {
+	// The tolerance is positive and the result is between -1 and 1, so
+	// no error can be returned
+	x, _ := CosWithin(f, DefaultTolerance.Frac())
+	return x
+}
@@ -0,0 +1,72 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestTranscendentalWithin(t *testing.T) {
+	tolerance := MustNew(1, 1000000000000)
+	fns := []struct {
+		name   string
+		within func(f, tolerance *Frac) (*Frac, error)
+		math   func(float64) float64
+	}{
+		{"sin", SinWithin, math.Sin},
+		{"cos", CosWithin, math.Cos},
+		{"tan", TanWithin, math.Tan},
+		{"atan", AtanWithin, math.Atan},
+		{"exp", ExpWithin, math.Exp},
+		{"log", LogWithin, math.Log},
+	}
+	args := []*Frac{MustNew(1, 3), MustNew(-7, 2), NewFromInt(10), MustNew(3, 2), MustNew(1, 1000000), MustNew(5, 2)}
+	for _, fn := range fns {
+		for _, arg := range args {
+			x := arg.Float64()
+			if fn.name == "log" && x <= 0 {
+				continue
+			}
+			f, err := fn.within(arg, tolerance)
+			if err != nil {
+				t.Fatalf("%s(%s): %v", fn.name, arg, err)
+			}
+			want := fn.math(x)
+			// Allow for the rounding errors of the float64 functions
+			if diff := math.Abs(f.Float64() - want); diff > 1e-12+math.Abs(want)*1e-15 {
+				t.Errorf("%s(%s): expected %v, got %s (%v), which differs by %v", fn.name, arg, want, f, f.Float64(), diff)
+			}
+		}
+	}
+}
+
+func TestTranscendentalErrors(t *testing.T) {
+	tolerance := MustNew(1, 1000)
+	if _, err := LogWithin(NewZero(), tolerance); err != ErrDomain {
+		t.Errorf("Expected ErrDomain, got %v", err)
+	}
+	if _, err := ExpWithin(NewFromInt(45), tolerance); err != ErrOverflow {
+		t.Errorf("Expected ErrOverflow, got %v", err)
+	}
+	if _, err := SinWithin(NewOne(), NewZero()); err != ErrInvalidTolerance {
+		t.Errorf("Expected ErrInvalidTolerance, got %v", err)
+	}
+	if f, err := ExpWithin(NewFromInt64(-math.MaxInt64), tolerance); err != nil || !f.IsZero() {
+		t.Errorf("Expected 0, got %v (%v)", f, err)
+	}
+	// A large argument needs many bits of π to be reduced correctly
+	f, err := SinWithin(NewFromInt64(math.MaxInt64), MustNew(1, 1000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(f.Float64()-0.5303352662202238) > 1e-6 {
+		t.Errorf("Expected sin(2^63-1) to be close to 0.530335, got %v", f.Float64())
+	}
+}
+
+func ExampleSinWithin() {
+	x, _ := SinWithin(MustNew(1, 2), MustNew(1, 1000))
+	fmt.Println(x)
+	// Output:
+	// 23⁄48
+}
@@ -1,17 +1,48 @@
 package num
 
-// Return the smallest number of two given integers
-func min(a, b int64) int64 {
-	if a < b {
-		return a
+// Return the absolute value of an integer as an unsigned integer.
+// This also works for math.MinInt64.
+func uabs(a int64) uint64 {
+	if a < 0 {
+		return uint64(-a)
 	}
-	return b
+	return uint64(a)
 }
 
-// Return the absolute value of an integer
-func abs(a int64) int64 {
-	if a < 0 {
-		return -a
+// Return the greatest common divisor of two unsigned integers
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
 	}
 	return a
 }
+
+// Multiply two integers, the bool is false if the result overflows
+func mul64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (c < 0) != ((a < 0) != (b < 0)) || c/b != a {
+		return 0, false
+	}
+	return c, true
+}
+
+// Add two integers, the bool is false if the result overflows
+func add64(a, b int64) (int64, bool) {
+	c := a + b
+	if (c > a) != (b > 0) {
+		return 0, false
+	}
+	return c, true
+}
+
+// Subtract two integers, the bool is false if the result overflows
+func sub64(a, b int64) (int64, bool) {
+	c := a - b
+	if (c < a) != (b > 0) {
+		return 0, false
+	}
+	return c, true
+}
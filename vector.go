@@ -0,0 +1,205 @@
+package num
+
+import (
+	"math/big"
+)
+
+// Vector is a vector of fractions, where all the calculations are exact.
+// The operations return new vectors, and leave the vector unchanged.
+// Vectors may also be used as points, for Orientation and Collinear.
+type Vector []*Frac
+
+// NewVector creates a new vector from copies of the given fractions
+func NewVector(elements ...*Frac) Vector {
+	v := make(Vector, len(elements))
+	for i, f := range elements {
+		v[i] = f.Copy()
+	}
+	return v
+}
+
+// NewVectorFromInt64 creates a new vector of whole numbers
+func NewVectorFromInt64(elements ...int64) Vector {
+	v := make(Vector, len(elements))
+	for i, n := range elements {
+		v[i] = NewFromInt64(n)
+	}
+	return v
+}
+
+// Copy creates a copy
+func (v Vector) Copy() Vector {
+	return NewVector(v...)
+}
+
+// Equal checks if the two vectors have the same length and elements
+func (v Vector) Equal(w Vector) bool {
+	if len(v) != len(w) {
+		return false
+	}
+	for i, f := range v {
+		if f.Cmp(w[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero checks if all the elements are 0
+func (v Vector) IsZero() bool {
+	for _, f := range v {
+		if !f.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// elementwise returns a new vector where each element is op(v[i], w[i])
+func (v Vector) elementwise(op func(a, b *Frac) (*Frac, error), w Vector) (Vector, error) {
+	if len(v) != len(w) {
+		return nil, ErrDimension
+	}
+	result := make(Vector, len(v))
+	for i := range v {
+		x, err := op(v[i], w[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = x
+	}
+	return result, nil
+}
+
+// Add returns v + w.
+// Returns ErrDimension if the lengths differ, and ErrOverflow.
+func (v Vector) Add(w Vector) (Vector, error) {
+	return v.elementwise(CheckedAdd, w)
+}
+
+// Sub returns v - w.
+// Returns ErrDimension if the lengths differ, and ErrOverflow.
+func (v Vector) Sub(w Vector) (Vector, error) {
+	return v.elementwise(CheckedSub, w)
+}
+
+// Mul returns the vector multiplied by the fraction, or ErrOverflow
+func (v Vector) Mul(f *Frac) (Vector, error) {
+	result := make(Vector, len(v))
+	for i, x := range v {
+		product, err := CheckedMul(x, f)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = product
+	}
+	return result, nil
+}
+
+// MulInt returns the vector multiplied by the integer, or ErrOverflow
+func (v Vector) MulInt(n int) (Vector, error) {
+	return v.Mul(NewFromInt(n))
+}
+
+// Dot returns the dot product of v and w.
+// Returns ErrDimension if the lengths differ, and ErrOverflow.
+func (v Vector) Dot(w Vector) (*Frac, error) {
+	products, err := v.elementwise(CheckedMul, w)
+	if err != nil {
+		return nil, err
+	}
+	sum := NewZero()
+	for _, x := range products {
+		if sum, err = CheckedAdd(sum, x); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+// Cross returns the cross product of v and w.
+// Returns ErrDimension if they are not both 3D vectors, and ErrOverflow.
+func (v Vector) Cross(w Vector) (Vector, error) {
+	if len(v) != 3 || len(w) != 3 {
+		return nil, ErrDimension
+	}
+	result := make(Vector, 3)
+	for i := range result {
+		j, k := (i+1)%3, (i+2)%3
+		a, err := CheckedMul(v[j], w[k])
+		if err != nil {
+			return nil, err
+		}
+		if result[i], err = mulSub(a, v[k], w[j]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Project returns the projection of v onto w, (v·w / w·w) w.
+// Returns ErrDimension if the lengths differ, ErrDivByZero if w is the
+// zero vector, and ErrOverflow.
+func (v Vector) Project(w Vector) (Vector, error) {
+	vw, err := v.Dot(w)
+	if err != nil {
+		return nil, err
+	}
+	ww, err := w.Dot(w)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := CheckedDiv(vw, ww)
+	if err != nil {
+		return nil, err
+	}
+	return w.Mul(scale)
+}
+
+// rats returns the elements as rational numbers (big.Rat)
+func (v Vector) rats() []*big.Rat {
+	rats := make([]*big.Rat, len(v))
+	for i, f := range v {
+		rats[i] = f.Rat()
+	}
+	return rats
+}
+
+// minor returns (b[i]-a[i])*(c[j]-a[j]) - (b[j]-a[j])*(c[i]-a[i])
+func minor(a, b, c []*big.Rat, i, j int) int {
+	bi := new(big.Rat).Sub(b[i], a[i])
+	bj := new(big.Rat).Sub(b[j], a[j])
+	ci := new(big.Rat).Sub(c[i], a[i])
+	cj := new(big.Rat).Sub(c[j], a[j])
+	return bi.Mul(bi, cj).Cmp(bj.Mul(bj, ci))
+}
+
+// Orientation returns 1 if the 2D points a, b and c turn counterclockwise,
+// -1 if they turn clockwise, and 0 if they are on a line. The result is
+// always exact, and can not overflow.
+// Returns ErrDimension if the points are not all 2D.
+func Orientation(a, b, c Vector) (int, error) {
+	if len(a) != 2 || len(b) != 2 || len(c) != 2 {
+		return 0, ErrDimension
+	}
+	return minor(a.rats(), b.rats(), c.rats(), 0, 1), nil
+}
+
+// Collinear checks if the points a, b and c are on a line. The points may
+// have any dimension, and the result is always exact.
+// Returns ErrDimension if the points do not have the same dimension.
+func Collinear(a, b, c Vector) (bool, error) {
+	if len(a) != len(b) || len(a) != len(c) {
+		return false, ErrDimension
+	}
+	ra, rb, rc := a.rats(), b.rats(), c.rats()
+	// b-a and c-a must be parallel, so every 2×2 minor must be 0
+	for i := range ra {
+		for j := i + 1; j < len(ra); j++ {
+			if minor(ra, rb, rc, i, j) != 0 {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
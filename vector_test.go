@@ -0,0 +1,131 @@
+package num
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestVectorArithmetic(t *testing.T) {
+	v := NewVector(MustNew(1, 2), MustNew(-1, 3), NewOne())
+	w := NewVectorFromInt64(2, 3, -4)
+
+	sum, err := v.Add(w)
+	if err != nil || fmt.Sprint(sum) != "[5⁄2 8⁄3 -3]" {
+		t.Errorf("expected [5⁄2 8⁄3 -3], got %v, %v", sum, err)
+	}
+	diff, err := v.Sub(w)
+	if err != nil || fmt.Sprint(diff) != "[-3⁄2 -10⁄3 5]" {
+		t.Errorf("expected [-3⁄2 -10⁄3 5], got %v, %v", diff, err)
+	}
+	scaled, err := v.MulInt(6)
+	if err != nil || fmt.Sprint(scaled) != "[3 -2 6]" {
+		t.Errorf("expected [3 -2 6], got %v, %v", scaled, err)
+	}
+	scaled, err = v.Mul(MustNew(3, 2))
+	if err != nil || fmt.Sprint(scaled) != "[3⁄4 -1⁄2 3⁄2]" {
+		t.Errorf("expected [3⁄4 -1⁄2 3⁄2], got %v, %v", scaled, err)
+	}
+	dot, err := v.Dot(w)
+	if err != nil || dot.String() != "-4" {
+		t.Errorf("expected -4, got %v, %v", dot, err)
+	}
+	// The vector is left unchanged
+	if fmt.Sprint(v) != "[½ -1⁄3 1]" {
+		t.Errorf("expected [½ -1⁄3 1], got %v", v)
+	}
+	if _, err := v.Dot(NewVectorFromInt64(1, 2)); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+	if _, err := NewVectorFromInt64(math.MaxInt64).MulInt(2); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestVectorCross(t *testing.T) {
+	x, y, z := NewVectorFromInt64(1, 0, 0), NewVectorFromInt64(0, 1, 0), NewVectorFromInt64(0, 0, 1)
+	if c, err := x.Cross(y); err != nil || !c.Equal(z) {
+		t.Errorf("expected %v, got %v, %v", z, c, err)
+	}
+	if c, err := z.Cross(y); err != nil || !c.Equal(NewVectorFromInt64(-1, 0, 0)) {
+		t.Errorf("expected [-1 0 0], got %v, %v", c, err)
+	}
+	v := NewVector(MustNew(1, 2), MustNew(2, 3), MustNew(3, 4))
+	w := NewVectorFromInt64(4, 5, 6)
+	c, err := v.Cross(w)
+	if err != nil || fmt.Sprint(c) != "[¼ 0 -1⁄6]" {
+		t.Errorf("expected [¼ 0 -1⁄6], got %v, %v", c, err)
+	}
+	// The cross product is orthogonal to both vectors
+	for _, u := range []Vector{v, w} {
+		if d, err := c.Dot(u); err != nil || !d.IsZero() {
+			t.Errorf("expected 0, got %v, %v", d, err)
+		}
+	}
+	if _, err := NewVectorFromInt64(1, 2).Cross(NewVectorFromInt64(3, 4)); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+}
+
+func TestVectorProject(t *testing.T) {
+	p, err := NewVectorFromInt64(3, 4).Project(NewVectorFromInt64(1, 1))
+	if err != nil || fmt.Sprint(p) != "[7⁄2 7⁄2]" {
+		t.Errorf("expected [7⁄2 7⁄2], got %v, %v", p, err)
+	}
+	p, err = NewVector(MustNew(1, 3), NewOne()).Project(NewVectorFromInt64(3, 0))
+	if err != nil || fmt.Sprint(p) != "[⅓ 0]" {
+		t.Errorf("expected [⅓ 0], got %v, %v", p, err)
+	}
+	if _, err := NewVectorFromInt64(1, 2).Project(NewVectorFromInt64(0, 0)); err != ErrDivByZero {
+		t.Errorf("expected ErrDivByZero, got %v", err)
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	tests := []struct {
+		a, b, c Vector
+		want    int
+	}{
+		{NewVectorFromInt64(0, 0), NewVectorFromInt64(1, 0), NewVectorFromInt64(0, 1), 1},
+		{NewVectorFromInt64(0, 0), NewVectorFromInt64(0, 1), NewVectorFromInt64(1, 0), -1},
+		{NewVectorFromInt64(0, 0), NewVectorFromInt64(1, 1), NewVectorFromInt64(3, 3), 0},
+		// Exactly on the line y = x/3
+		{NewVectorFromInt64(0, 0), NewVector(NewOne(), MustNew(1, 3)), NewVector(MustNew(1, 7), MustNew(1, 21)), 0},
+		// Just above the line, by less than a float64 can show
+		{NewVectorFromInt64(0, 0), NewVector(NewOne(), MustNew(1, 3)), NewVector(MustNew(3, 1), MustNew(1000000000000000001, 1000000000000000000)), 1},
+		// Large coordinates, where the products do not fit in an int64
+		{NewVectorFromInt64(math.MinInt64, math.MinInt64), NewVectorFromInt64(math.MaxInt64, math.MaxInt64), NewVectorFromInt64(0, -1), -1},
+	}
+	for _, test := range tests {
+		got, err := Orientation(test.a, test.b, test.c)
+		if err != nil {
+			t.Errorf("%v %v %v: %v", test.a, test.b, test.c, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%v %v %v: expected %d, got %d", test.a, test.b, test.c, test.want, got)
+		}
+		collinear, err := Collinear(test.a, test.b, test.c)
+		if err != nil || collinear != (test.want == 0) {
+			t.Errorf("%v %v %v: expected collinear to be %v, got %v, %v", test.a, test.b, test.c, test.want == 0, collinear, err)
+		}
+	}
+	if _, err := Orientation(NewVectorFromInt64(0, 0, 0), NewVectorFromInt64(1, 0, 0), NewVectorFromInt64(0, 1, 0)); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+}
+
+func TestCollinear3D(t *testing.T) {
+	a, b := NewVectorFromInt64(1, 2, 3), NewVectorFromInt64(4, 6, 8)
+	on := NewVector(MustNew(5, 2), NewFromInt64(4), MustNew(11, 2))
+	off := NewVector(MustNew(5, 2), NewFromInt64(4), MustNew(11, 3))
+	if collinear, err := Collinear(a, b, on); err != nil || !collinear {
+		t.Errorf("expected collinear, got %v, %v", collinear, err)
+	}
+	if collinear, err := Collinear(a, b, off); err != nil || collinear {
+		t.Errorf("expected not collinear, got %v, %v", collinear, err)
+	}
+	if _, err := Collinear(a, b, NewVectorFromInt64(1, 2)); err != ErrDimension {
+		t.Errorf("expected ErrDimension, got %v", err)
+	}
+}